@@ -0,0 +1,35 @@
+package cmd
+
+import (
+    "fmt"
+    "os"
+
+    "docker-manager/internal/docker"
+
+    "github.com/spf13/cobra"
+)
+
+// runEach applies op to every container in args and prints each one that
+// succeeded, like the docker CLI. A failure doesn't stop the rest, but the
+// command exits non-zero.
+func runEach(cmd *cobra.Command, args []string, verb string, op func(runtime docker.ContainerRuntime, id string) error) {
+    runtime, err := newRuntime()
+    if err != nil {
+        fmt.Printf("Error connecting to Docker: %v\n", err)
+        os.Exit(1)
+    }
+
+    failed := false
+    out := cmd.OutOrStdout()
+    for _, id := range args {
+        if err := op(runtime, id); err != nil {
+            fmt.Fprintln(out, colorize(colorRed, fmt.Sprintf("Error %s %s: %v", verb, id, err)))
+            failed = true
+            continue
+        }
+        fmt.Fprintln(out, id)
+    }
+    if failed {
+        os.Exit(1)
+    }
+}
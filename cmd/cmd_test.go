@@ -0,0 +1,69 @@
+package cmd
+
+import (
+    "bytes"
+    "testing"
+    "time"
+
+    "docker-manager/internal/docker"
+)
+
+// newTestRuntime returns a fake with two running containers, one of them
+// with stats, and a stopped one.
+func newTestRuntime() *docker.FakeRuntime {
+    created := time.Now().Add(-time.Hour)
+    fake := docker.NewFakeRuntime(
+        docker.ContainerInfo{
+            ID: "aaaaaaaaaaaa", Name: "web", Image: "nginx:1.25",
+            State: "running", Status: "Up 1 hour", Created: created,
+            Labels: map[string]string{"tier": "frontend"},
+        },
+        docker.ContainerInfo{
+            ID: "bbbbbbbbbbbb", Name: "db", Image: "postgres:16",
+            State: "running", Status: "Up 1 hour (healthy)", Health: "healthy", Created: created,
+            Labels: map[string]string{"tier": "backend"},
+        },
+        docker.ContainerInfo{
+            ID: "cccccccccccc", Name: "job", Image: "busybox",
+            State: "exited", Status: "Exited (0) 5 minutes ago", Created: created,
+        },
+    )
+    fake.Stats["aaaaaaaaaaaa"] = &docker.ContainerStats{
+        CPU:       12.5,
+        Memory:    25,
+        MemUsage:  256 << 20,
+        MemLimit:  1 << 30,
+        NetRx:     3 << 20,
+        NetTx:     1 << 20,
+        NetRxRate: 2048,
+        NetTxRate: 512,
+        HasRates:  true,
+        Network:   "↓2.0KiB/s ↑512B/s",
+        PIDs:      4,
+    }
+    return fake
+}
+
+// runCommand runs the CLI with args against runtime and returns what it
+// printed. Flags are reset first since cobra keeps their values between
+// runs.
+func runCommand(t *testing.T, runtime docker.ContainerRuntime, args ...string) string {
+    t.Helper()
+
+    listAll, listStats, listFormat, listFilter = false, false, "table", nil
+    statsFormat, statsNoStream, statsFilter = "table", false, nil
+    execAll, execFilter, execParallel, execFormat = false, nil, 4, "text"
+    tailLines, logsFollow, logsLabels, logsFilter, logsProject, logsOutput = 100, false, nil, nil, "", ""
+
+    prev := newRuntime
+    newRuntime = func() (docker.ContainerRuntime, error) { return runtime, nil }
+    t.Cleanup(func() { newRuntime = prev })
+
+    var out bytes.Buffer
+    rootCmd.SetOut(&out)
+    rootCmd.SetArgs(args)
+    if err := rootCmd.Execute(); err != nil {
+        t.Fatalf("%v: %v", args, err)
+    }
+    return out.String()
+}
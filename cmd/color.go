@@ -0,0 +1,59 @@
+package cmd
+
+import (
+    "io"
+    "os"
+
+    "golang.org/x/term"
+)
+
+const (
+    colorRed     = "\033[31m"
+    colorGreen   = "\033[32m"
+    colorYellow  = "\033[33m"
+    colorBlue    = "\033[34m"
+    colorMagenta = "\033[35m"
+    colorCyan    = "\033[36m"
+    colorReset   = "\033[0m"
+)
+
+// prefixColors tell containers apart in merged logs. Red is left out so
+// it keeps meaning stderr.
+var prefixColors = []string{colorCyan, colorYellow, colorGreen, colorMagenta, colorBlue}
+
+var (
+    noColor bool
+
+    // colorEnabled is decided once per run by setupColor
+    colorEnabled = true
+)
+
+// setupColor turns colors off for --no-color, NO_COLOR, or when out is not
+// a terminal (e.g. piped into another program).
+func setupColor(out io.Writer) {
+    colorEnabled = !noColor && os.Getenv("NO_COLOR") == "" && isTerminal(out)
+}
+
+func isTerminal(w io.Writer) bool {
+    f, ok := w.(*os.File)
+    return ok && term.IsTerminal(int(f.Fd()))
+}
+
+func colorize(color, s string) string {
+    if !colorEnabled || color == "" {
+        return s
+    }
+    return color + s + colorReset
+}
+
+// usageColor matches the thresholds used by the TUI.
+func usageColor(value float64) string {
+    switch {
+    case value > 80:
+        return colorRed
+    case value > 60:
+        return colorYellow
+    default:
+        return colorGreen
+    }
+}
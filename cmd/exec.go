@@ -0,0 +1,247 @@
+package cmd
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+    "time"
+
+    "docker-manager/internal/docker"
+
+    "github.com/spf13/cobra"
+)
+
+var (
+    execInteractive bool
+    execTTY         bool
+    execUser        string
+    execWorkdir     string
+    execEnv         []string
+
+    execAll      bool
+    execFilter   []string
+    execParallel int
+    execFormat   string
+)
+
+var execCmd = &cobra.Command{
+    Use:   "exec container [command...] | exec --all [--filter key=value] command...",
+    Short: "Run a command in a running container",
+    Long: `Run a command inside a running container, like docker exec. The exit code is
+the command's.
+
+Without a command an interactive shell is started: bash if the container has
+it, otherwise sh.
+
+--all runs a non-interactive command in every running container instead,
+narrowed down by --filter, with up to --parallel commands at a time. The output
+of each container is reported together once all have finished. The command
+fails if it failed in any container.`,
+    Args: cobra.MinimumNArgs(1),
+    Run: func(cmd *cobra.Command, args []string) {
+        if execAll || len(execFilter) > 0 {
+            execEach(cmd, args)
+            return
+        }
+
+        opts := docker.ExecOptions{
+            Cmd:        args[1:],
+            User:       execUser,
+            WorkingDir: execWorkdir,
+            Env:        execEnv,
+            Tty:        execTTY,
+            Stdin:      execInteractive,
+        }
+        if len(opts.Cmd) == 0 {
+            opts.Cmd = docker.ShellCommand
+            opts.Stdin = true
+            opts.Tty = isTerminal(os.Stdin)
+        }
+        if opts.Tty && !isTerminal(os.Stdin) {
+            fmt.Println("Error: --tty needs stdin to be a terminal")
+            os.Exit(1)
+        }
+        opts.Width, opts.Height = docker.TerminalSize(os.Stdin)
+
+        runtime, err := newRuntime()
+        if err != nil {
+            fmt.Printf("Error connecting to Docker: %v\n", err)
+            os.Exit(1)
+        }
+
+        session, err := runtime.ExecContainer(context.Background(), args[0], opts)
+        if err != nil {
+            fmt.Printf("Error running command in %s: %v\n", args[0], err)
+            os.Exit(1)
+        }
+
+        var stdin io.Reader
+        if opts.Stdin {
+            stdin = os.Stdin
+        }
+        err = session.Attach(stdin, cmd.OutOrStdout(), os.Stderr)
+        code := 0
+        if err == nil {
+            code, err = session.ExitCode()
+        }
+        session.Close()
+        if err != nil {
+            fmt.Printf("Error running command in %s: %v\n", args[0], err)
+            os.Exit(1)
+        }
+        os.Exit(code)
+    },
+}
+
+func init() {
+    // Flags after the container belong to the command
+    execCmd.Flags().SetInterspersed(false)
+    execCmd.Flags().BoolVarP(&execInteractive, "interactive", "i", false, "Keep stdin attached to the command")
+    execCmd.Flags().BoolVarP(&execTTY, "tty", "t", false, "Allocate a terminal")
+    execCmd.Flags().StringVarP(&execUser, "user", "u", "", "Run as this user (name or uid[:gid])")
+    execCmd.Flags().StringVarP(&execWorkdir, "workdir", "w", "", "Working directory inside the container")
+    execCmd.Flags().StringArrayVarP(&execEnv, "env", "e", nil, "Set an environment variable (KEY=value)")
+    execCmd.Flags().BoolVar(&execAll, "all", false, "Run the command in every running container")
+    execCmd.Flags().StringArrayVar(&execFilter, "filter", nil, filterHelp+"; implies --all")
+    execCmd.Flags().IntVar(&execParallel, "parallel", 4, "How many containers to run the command in at once (with --all)")
+    execCmd.Flags().StringVar(&execFormat, "format", "text", "Report format with --all: text or json")
+}
+
+// execEach runs command in every running container matching --filter and
+// reports the results grouped by container.
+func execEach(cmd *cobra.Command, command []string) {
+    switch {
+    case execInteractive || execTTY:
+        fmt.Println("Error: --interactive and --tty can't be used with --all")
+        os.Exit(1)
+    case execParallel < 1:
+        fmt.Println("Error: --parallel must be at least 1")
+        os.Exit(1)
+    case execFormat != "text" && execFormat != "json":
+        fmt.Printf("Error: unknown format %q (want text or json)\n", execFormat)
+        os.Exit(1)
+    }
+    filters := parseFilterFlags(execFilter)
+
+    runtime, err := newRuntime()
+    if err != nil {
+        fmt.Printf("Error connecting to Docker: %v\n", err)
+        os.Exit(1)
+    }
+
+    containers, err := runtime.ListContainers(docker.ListOptions{SkipStats: true, Filters: filters})
+    if err != nil {
+        fmt.Printf("Error listing containers: %v\n", err)
+        os.Exit(1)
+    }
+    if len(containers) == 0 {
+        fmt.Println("Error: no running containers match")
+        os.Exit(1)
+    }
+
+    opts := docker.ExecOptions{
+        Cmd:        command,
+        User:       execUser,
+        WorkingDir: execWorkdir,
+        Env:        execEnv,
+    }
+    results := docker.ExecAll(context.Background(), runtime, containers, opts, execParallel)
+
+    out := cmd.OutOrStdout()
+    if execFormat == "json" {
+        err = writeExecJSON(out, results)
+    } else {
+        writeExecReport(out, results)
+    }
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        os.Exit(1)
+    }
+
+    for _, r := range results {
+        if r.Failed() {
+            os.Exit(1)
+        }
+    }
+}
+
+// writeExecReport prints each container's output under a header with its
+// exit code, then a summary.
+func writeExecReport(w io.Writer, results []docker.ExecResult) {
+    failed := 0
+    for i, r := range results {
+        if i > 0 {
+            fmt.Fprintln(w)
+        }
+
+        status := fmt.Sprintf("exit %d", r.ExitCode)
+        color := colorGreen
+        if r.Err != nil {
+            status = "error"
+        }
+        if r.Failed() {
+            color = colorRed
+            failed++
+        }
+        header := fmt.Sprintf("=== %s: %s (%s) ===", r.Container.Name, status, r.Duration.Round(time.Millisecond))
+        fmt.Fprintln(w, colorize(color, header))
+
+        if r.Err != nil {
+            fmt.Fprintln(w, colorize(colorRed, r.Err.Error()))
+        }
+        writeExecOutput(w, r.Stdout, "")
+        writeExecOutput(w, r.Stderr, colorRed)
+    }
+
+    fmt.Fprintln(w)
+    summary := fmt.Sprintf("%d of %d containers succeeded", len(results)-failed, len(results))
+    if failed > 0 {
+        fmt.Fprintln(w, colorize(colorRed, summary))
+        return
+    }
+    fmt.Fprintln(w, colorize(colorGreen, summary))
+}
+
+func writeExecOutput(w io.Writer, output, color string) {
+    output = strings.TrimRight(output, "\n")
+    if output == "" {
+        return
+    }
+    for _, line := range strings.Split(output, "\n") {
+        fmt.Fprintln(w, colorize(color, line))
+    }
+}
+
+func writeExecJSON(w io.Writer, results []docker.ExecResult) error {
+    type execReport struct {
+        Container  string `json:"container"`
+        ID         string `json:"id"`
+        ExitCode   int    `json:"exit_code"`
+        Stdout     string `json:"stdout"`
+        Stderr     string `json:"stderr"`
+        DurationMS int64  `json:"duration_ms"`
+        Error      string `json:"error,omitempty"`
+    }
+
+    reports := make([]execReport, len(results))
+    for i, r := range results {
+        reports[i] = execReport{
+            Container:  r.Container.Name,
+            ID:         r.Container.ID,
+            ExitCode:   r.ExitCode,
+            Stdout:     r.Stdout,
+            Stderr:     r.Stderr,
+            DurationMS: r.Duration.Milliseconds(),
+        }
+        if r.Err != nil {
+            reports[i].Error = r.Err.Error()
+        }
+    }
+
+    enc := json.NewEncoder(w)
+    enc.SetIndent("", "  ")
+    return enc.Encode(reports)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+    "encoding/json"
+    "errors"
+    "os"
+    "os/exec"
+    "testing"
+
+    "docker-manager/internal/docker"
+)
+
+func TestExecAllJSON(t *testing.T) {
+    fake := newTestRuntime()
+    fake.Execs["aaaaaaaaaaaa"] = docker.FakeExec{Stdout: "web\n"}
+    fake.Execs["bbbbbbbbbbbb"] = docker.FakeExec{Stdout: "db\n", Stderr: "warning\n"}
+
+    out := runCommand(t, fake, "exec", "--all", "--format", "json", "hostname")
+
+    var reports []map[string]interface{}
+    if err := json.Unmarshal([]byte(out), &reports); err != nil {
+        t.Fatalf("invalid JSON: %v\n%s", err, out)
+    }
+    // The stopped job is left out and the rest keep the list's order
+    if len(reports) != 2 || reports[0]["container"] != "web" || reports[1]["container"] != "db" {
+        t.Fatalf("got %+v, want web then db", reports)
+    }
+    want := map[string]interface{}{
+        "container": "db",
+        "id":        "bbbbbbbbbbbb",
+        "exit_code": float64(0),
+        "stdout":    "db\n",
+        "stderr":    "warning\n",
+    }
+    for key, value := range want {
+        if reports[1][key] != value {
+            t.Errorf("%s = %v, want %v", key, reports[1][key], value)
+        }
+    }
+    if _, ok := reports[1]["duration_ms"].(float64); !ok {
+        t.Errorf("duration_ms missing: %+v", reports[1])
+    }
+    if _, ok := reports[1]["error"]; ok || len(reports[1]) != len(want)+1 {
+        t.Errorf("unexpected fields: %+v", reports[1])
+    }
+}
+
+// TestExecAllFailureExitCode runs the command in a child process since it
+// exits the process when a container fails.
+func TestExecAllFailureExitCode(t *testing.T) {
+    if os.Getenv("EXEC_ALL_CHILD") == "1" {
+        fake := newTestRuntime()
+        fake.Execs["bbbbbbbbbbbb"] = docker.FakeExec{ExitCode: 3}
+        newRuntime = func() (docker.ContainerRuntime, error) { return fake, nil }
+        rootCmd.SetOut(os.Stdout)
+        rootCmd.SetArgs([]string{"exec", "--all", "--format", "json", "false"})
+        rootCmd.Execute()
+        return
+    }
+
+    child := exec.Command(os.Args[0], "-test.run=^TestExecAllFailureExitCode$")
+    child.Env = append(os.Environ(), "EXEC_ALL_CHILD=1")
+    out, err := child.Output()
+
+    var exit *exec.ExitError
+    if !errors.As(err, &exit) || exit.ExitCode() != 1 {
+        t.Fatalf("err = %v, want exit code 1", err)
+    }
+    var reports []struct {
+        Container string `json:"container"`
+        ExitCode  int    `json:"exit_code"`
+    }
+    if err := json.Unmarshal(out, &reports); err != nil {
+        t.Fatalf("invalid JSON: %v\n%s", err, out)
+    }
+    if len(reports) != 2 || reports[0].ExitCode != 0 || reports[1].Container != "db" || reports[1].ExitCode != 3 {
+        t.Errorf("got %+v, want db exiting with 3", reports)
+    }
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+    "fmt"
+    "os"
+
+    "docker-manager/internal/docker"
+)
+
+const filterHelp = "Filter containers by key=value (label, status, name, ancestor, network, health, project); repeatable"
+
+// parseFilterFlags validates --filter values before anything is sent to the
+// daemon.
+func parseFilterFlags(values []string) []docker.Filter {
+    filters, err := docker.ParseFilters(values)
+    if err != nil {
+        fmt.Printf("Error: %v\n", err)
+        os.Exit(1)
+    }
+    return filters
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+    "encoding/csv"
+    "encoding/json"
+    "fmt"
+    "io"
+    "strconv"
+    "strings"
+    "text/template"
+    "time"
+
+    "docker-manager/internal/docker"
+
+    "gopkg.in/yaml.v3"
+)
+
+const formatHelp = `Output format: table, json, yaml, csv or a Go template (e.g. '{{.Name}}\t{{.Status}}')`
+
+var csvHeader = []string{
+    "id", "name", "image", "state", "status", "health", "ports", "created",
+    "cpu_percent", "memory_percent", "memory_usage_bytes", "memory_limit_bytes",
+    "net_rx_bytes", "net_tx_bytes", "net_rx_rate", "net_tx_rate",
+    "block_read_bytes", "block_write_bytes", "pids",
+}
+
+var templateFuncs = template.FuncMap{
+    "json": func(v interface{}) (string, error) {
+        b, err := json.Marshal(v)
+        return string(b), err
+    },
+    "upper": strings.ToUpper,
+    "lower": strings.ToLower,
+    "bytes": docker.FormatBytes,
+    "rate":  docker.FormatRate,
+}
+
+// renderContainers writes containers in the requested format. The table
+// format is left to the caller since each command lays it out differently.
+func renderContainers(w io.Writer, format string, containers []docker.ContainerInfo, table func(io.Writer, []docker.ContainerInfo)) error {
+    // Keep empty results as [] rather than null
+    if containers == nil {
+        containers = []docker.ContainerInfo{}
+    }
+
+    switch format {
+    case "", "table":
+        table(w, containers)
+        return nil
+
+    case "json":
+        enc := json.NewEncoder(w)
+        enc.SetIndent("", "  ")
+        return enc.Encode(containers)
+
+    case "yaml":
+        enc := yaml.NewEncoder(w)
+        defer enc.Close()
+        return enc.Encode(containers)
+
+    case "csv":
+        return writeCSV(w, containers)
+
+    default:
+        return writeTemplate(w, format, containers)
+    }
+}
+
+func isTableFormat(format string) bool {
+    return format == "" || format == "table"
+}
+
+func writeCSV(w io.Writer, containers []docker.ContainerInfo) error {
+    cw := csv.NewWriter(w)
+    if err := cw.Write(csvHeader); err != nil {
+        return err
+    }
+
+    f := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+    u := func(v uint64) string { return strconv.FormatUint(v, 10) }
+    for _, c := range containers {
+        record := []string{
+            c.ID, c.Name, c.Image, c.State, c.Status, c.Health, strings.TrimSpace(c.Ports),
+            c.Created.Format(time.RFC3339),
+            f(c.CPU), f(c.Memory), u(c.MemUsage), u(c.MemLimit),
+            u(c.NetRx), u(c.NetTx), f(c.NetRxRate), f(c.NetTxRate),
+            u(c.BlockRead), u(c.BlockWrite), u(c.PIDs),
+        }
+        if err := cw.Write(record); err != nil {
+            return err
+        }
+    }
+    cw.Flush()
+    return cw.Error()
+}
+
+// parseFormatTemplate parses a --format template with templateFuncs.
+func parseFormatTemplate(format string) (*template.Template, error) {
+    // Allow escaped tabs and newlines as typed on a shell command line
+    format = strings.NewReplacer(`\t`, "\t", `\n`, "\n").Replace(format)
+
+    tmpl, err := template.New("format").Funcs(templateFuncs).Parse(format)
+    if err != nil {
+        return nil, fmt.Errorf("invalid format template: %w", err)
+    }
+    return tmpl, nil
+}
+
+func writeTemplate(w io.Writer, format string, containers []docker.ContainerInfo) error {
+    tmpl, err := parseFormatTemplate(format)
+    if err != nil {
+        return err
+    }
+    for _, c := range containers {
+        if err := tmpl.Execute(w, c); err != nil {
+            return err
+        }
+        fmt.Fprintln(w)
+    }
+    return nil
+}
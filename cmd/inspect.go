@@ -0,0 +1,259 @@
+package cmd
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+
+    "docker-manager/internal/docker"
+
+    "github.com/spf13/cobra"
+)
+
+var (
+    inspectFormat string
+    inspectField  string
+    inspectDiff   bool
+)
+
+// diffSections are compared by --diff unless --field picks something else.
+// State and NetworkSettings are left out since they differ between any two
+// containers.
+var diffSections = []string{"Name", "Image", "Config", "HostConfig", "Mounts"}
+
+var inspectCmd = &cobra.Command{
+    Use:   "inspect container [container...]",
+    Short: "Show low-level details of containers",
+    Long: `Print the full inspect JSON of one or more containers, as returned by the daemon.
+
+--format renders each container with a Go template instead, e.g.
+'{{.State.Status}}' or '{{json .Config.Labels}}'.
+
+--field selects a single value with a JSONPath-style selector, e.g.
+'.State.Health.Status', 'Config.Env[0]' or
+'Config.Labels["com.docker.compose.service"]'.
+
+--diff compares the configuration of two containers side by side. Pass
+--field as well to compare another part of the inspect output, or '$' to
+compare all of it.`,
+    Args: cobra.MinimumNArgs(1),
+    Run: func(cmd *cobra.Command, args []string) {
+        if inspectFormat != "" && inspectField != "" {
+            fmt.Println("Error: --format and --field can't be used together")
+            os.Exit(1)
+        }
+        if inspectDiff && len(args) != 2 {
+            fmt.Println("Error: --diff needs exactly two containers")
+            os.Exit(1)
+        }
+
+        runtime, err := newRuntime()
+        if err != nil {
+            fmt.Printf("Error connecting to Docker: %v\n", err)
+            os.Exit(1)
+        }
+
+        // Errors go to stderr so that stdout stays valid JSON for the
+        // containers that were found
+        var found []*docker.ContainerDetails
+        for _, id := range args {
+            details, err := runtime.InspectContainer(id)
+            if err != nil {
+                fmt.Fprintln(os.Stderr, colorize(colorRed, fmt.Sprintf("Error inspecting %s: %v", id, err)))
+                continue
+            }
+            found = append(found, details)
+        }
+        if len(found) < len(args) && (inspectDiff || len(found) == 0) {
+            os.Exit(1)
+        }
+
+        out := cmd.OutOrStdout()
+        switch {
+        case inspectDiff:
+            err = diffContainers(out, found[0], found[1], inspectField)
+        case inspectFormat != "":
+            err = writeInspectTemplate(out, inspectFormat, found)
+        case inspectField != "":
+            err = writeInspectField(out, inspectField, found)
+        default:
+            err = writeInspectJSON(out, found)
+        }
+        if err != nil {
+            fmt.Printf("Error: %v\n", err)
+            os.Exit(1)
+        }
+        if len(found) < len(args) {
+            os.Exit(1)
+        }
+    },
+}
+
+func init() {
+    inspectCmd.Flags().StringVar(&inspectFormat, "format", "", "Format the output using a Go template")
+    inspectCmd.Flags().StringVar(&inspectField, "field", "", "Print a single field, e.g. .State.Health.Status")
+    inspectCmd.Flags().BoolVar(&inspectDiff, "diff", false, "Compare the configuration of two containers")
+}
+
+// writeInspectJSON prints the daemon's JSON as an array, like docker
+// inspect, keeping its field order.
+func writeInspectJSON(w io.Writer, containers []*docker.ContainerDetails) error {
+    raw := make([]json.RawMessage, len(containers))
+    for i, d := range containers {
+        raw[i] = d.Raw
+    }
+    b, err := json.MarshalIndent(raw, "", "  ")
+    if err != nil {
+        return err
+    }
+    _, err = fmt.Fprintln(w, string(b))
+    return err
+}
+
+func decodeInspect(d *docker.ContainerDetails) (interface{}, error) {
+    var doc interface{}
+    if err := json.Unmarshal(d.Raw, &doc); err != nil {
+        return nil, fmt.Errorf("decoding %s: %w", d.Name, err)
+    }
+    return doc, nil
+}
+
+func writeInspectTemplate(w io.Writer, format string, containers []*docker.ContainerDetails) error {
+    tmpl, err := parseFormatTemplate(format)
+    if err != nil {
+        return err
+    }
+    for _, d := range containers {
+        doc, err := decodeInspect(d)
+        if err != nil {
+            return err
+        }
+        if err := tmpl.Execute(w, doc); err != nil {
+            return err
+        }
+        fmt.Fprintln(w)
+    }
+    return nil
+}
+
+// writeInspectField prints the selected field of every container. With
+// several containers each value is prefixed with the container's name.
+func writeInspectField(w io.Writer, field string, containers []*docker.ContainerDetails) error {
+    segments, err := parseFieldPath(field)
+    if err != nil {
+        return err
+    }
+    for _, d := range containers {
+        doc, err := decodeInspect(d)
+        if err != nil {
+            return err
+        }
+        v, err := lookupField(doc, segments)
+        if err != nil {
+            return fieldError(d, field, err)
+        }
+        if len(containers) > 1 {
+            fmt.Fprintf(w, "%s: ", d.Name)
+        }
+        fmt.Fprintln(w, formatField(v))
+    }
+    return nil
+}
+
+// diffContainers prints the fields that differ between two containers in
+// three columns: the field, a's value and b's value.
+func diffContainers(w io.Writer, a, b *docker.ContainerDetails, field string) error {
+    left, err := flattenInspect(a, field)
+    if err != nil {
+        return err
+    }
+    right, err := flattenInspect(b, field)
+    if err != nil {
+        return err
+    }
+
+    union := make(map[string]string, len(left))
+    for k := range left {
+        union[k] = ""
+    }
+    for k := range right {
+        union[k] = ""
+    }
+
+    type row struct{ path, left, right, color string }
+    var rows []row
+    for _, path := range sortedKeys(union) {
+        l, inLeft := left[path]
+        r, inRight := right[path]
+        switch {
+        case !inLeft:
+            rows = append(rows, row{path, "-", r, colorGreen})
+        case !inRight:
+            rows = append(rows, row{path, l, "-", colorRed})
+        case l != r:
+            rows = append(rows, row{path, l, r, colorYellow})
+        }
+    }
+
+    if len(rows) == 0 {
+        fmt.Fprintf(w, "No differences between %s and %s\n", a.Name, b.Name)
+        return nil
+    }
+
+    pathWidth, leftWidth := len("FIELD"), len(a.Name)
+    for _, r := range rows {
+        pathWidth = max(pathWidth, len(r.path))
+        leftWidth = max(leftWidth, len(r.left))
+    }
+
+    fmt.Fprintf(w, "%-*s   %-*s   %s\n", pathWidth, "FIELD", leftWidth, a.Name, b.Name)
+    for _, r := range rows {
+        // Pad before coloring so escape codes don't throw off the columns
+        fmt.Fprintf(w, "%s   %s   %s\n",
+            fmt.Sprintf("%-*s", pathWidth, r.path),
+            colorize(r.color, fmt.Sprintf("%-*s", leftWidth, r.left)),
+            colorize(r.color, r.right))
+    }
+    return nil
+}
+
+// flattenInspect flattens the part of a container's inspect output that
+// --diff compares: field if given, otherwise diffSections.
+func flattenInspect(d *docker.ContainerDetails, field string) (map[string]string, error) {
+    doc, err := decodeInspect(d)
+    if err != nil {
+        return nil, err
+    }
+
+    flat := make(map[string]string)
+    if field != "" {
+        segments, err := parseFieldPath(field)
+        if err != nil {
+            return nil, err
+        }
+        v, err := lookupField(doc, segments)
+        if err != nil {
+            return nil, fieldError(d, field, err)
+        }
+        flattenJSON(strings.Trim(strings.TrimPrefix(field, "$"), "."), v, flat)
+        return flat, nil
+    }
+
+    for _, section := range diffSections {
+        if v, err := lookupField(doc, []pathSegment{{key: section}}); err == nil {
+            flattenJSON(section, v, flat)
+        }
+    }
+    return flat, nil
+}
+
+// fieldError reports why field couldn't be looked up in d.
+func fieldError(d *docker.ContainerDetails, field string, err error) error {
+    if errors.Is(err, errNoField) {
+        return fmt.Errorf("%s has no field %s", d.Name, field)
+    }
+    return fmt.Errorf("%s: field %s: %w", d.Name, field, err)
+}
@@ -11,20 +11,30 @@ import (
     tea "github.com/charmbracelet/bubbletea"
 )
 
-var compactMode bool
+var (
+    compactMode bool
+    stopTimeout int
+    stopSig     string
+)
 
 var interactiveCmd = &cobra.Command{
     Use:   "interactive",
     Short: "Launch interactive TUI mode",
     Long:  `Launch the full interactive terminal UI for Docker container management.`,
     Run: func(cmd *cobra.Command, args []string) {
-        dockerClient, err := docker.NewDockerClient()
+        runtime, err := newRuntime()
         if err != nil {
             fmt.Printf("Error connecting to Docker: %v\n", err)
             os.Exit(1)
         }
 
-        model := ui.NewModel(dockerClient, compactMode)
+        model := ui.NewModel(runtime, compactMode)
+        opts := docker.StopOptions{Signal: stopSig}
+        if cmd.Flags().Changed("stop-timeout") {
+            opts.Timeout = &stopTimeout
+        }
+        model.SetStopOptions(opts)
+
         p := tea.NewProgram(model, tea.WithAltScreen())
 
         if _, err := p.Run(); err != nil {
@@ -36,4 +46,6 @@ var interactiveCmd = &cobra.Command{
 
 func init() {
     interactiveCmd.Flags().BoolVarP(&compactMode, "compact", "c", false, "Use compact view")
+    interactiveCmd.Flags().IntVar(&stopTimeout, "stop-timeout", 10, "Seconds stop and restart wait before killing a container (-1 waits forever)")
+    interactiveCmd.Flags().StringVar(&stopSig, "stop-signal", "", "Signal stop and restart send first (default: the container's stop signal)")
 }
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "sort"
+    "strconv"
+    "strings"
+)
+
+// pathSegment is one step of a field path: a key of an object or an index
+// into an array.
+type pathSegment struct {
+    key     string
+    index   int
+    isIndex bool
+}
+
+// parseFieldPath parses a JSONPath-style selector such as
+// ".State.Health.Status", "Config.Env[0]" or
+// `Config.Labels["com.docker.compose.project"]`. A leading "$" is allowed,
+// and "$" or "." alone selects the whole document.
+func parseFieldPath(path string) ([]pathSegment, error) {
+    p := strings.TrimPrefix(strings.TrimSpace(path), "$")
+    var segments []pathSegment
+
+    for p != "" {
+        switch p[0] {
+        case '.':
+            p = p[1:]
+
+        case '[':
+            end := strings.IndexByte(p, ']')
+            if end < 0 {
+                return nil, fmt.Errorf("invalid field %q: missing ]", path)
+            }
+            inner := p[1:end]
+            if unquoted, err := strconv.Unquote(inner); err == nil {
+                segments = append(segments, pathSegment{key: unquoted})
+            } else if n, err := strconv.Atoi(inner); err == nil && n >= 0 {
+                segments = append(segments, pathSegment{index: n, isIndex: true})
+            } else {
+                return nil, fmt.Errorf("invalid field %q: [%s] is neither an index nor a quoted key", path, inner)
+            }
+            p = p[end+1:]
+
+        default:
+            end := strings.IndexAny(p, ".[")
+            if end < 0 {
+                end = len(p)
+            }
+            segments = append(segments, pathSegment{key: p[:end]})
+            p = p[end:]
+        }
+    }
+    return segments, nil
+}
+
+// errNoField is returned by lookupField when the document has nothing at
+// the path.
+var errNoField = errors.New("no such field")
+
+// lookupField walks a decoded JSON document. Keys match exactly first and
+// then case-insensitively, so "state.status" finds "State.Status". A key
+// that matches several others case-insensitively is reported as ambiguous.
+func lookupField(doc interface{}, segments []pathSegment) (interface{}, error) {
+    v := doc
+    for _, seg := range segments {
+        switch node := v.(type) {
+        case map[string]interface{}:
+            if seg.isIndex {
+                return nil, errNoField
+            }
+            next, ok := node[seg.key]
+            if !ok {
+                var matches []string
+                for k := range node {
+                    if strings.EqualFold(k, seg.key) {
+                        matches = append(matches, k)
+                    }
+                }
+                switch len(matches) {
+                case 0:
+                    return nil, errNoField
+                case 1:
+                    next = node[matches[0]]
+                default:
+                    sort.Strings(matches)
+                    return nil, fmt.Errorf("%s is ambiguous: it matches %s", seg.key, strings.Join(matches, ", "))
+                }
+            }
+            v = next
+
+        case []interface{}:
+            if !seg.isIndex || seg.index >= len(node) {
+                return nil, errNoField
+            }
+            v = node[seg.index]
+
+        default:
+            return nil, errNoField
+        }
+    }
+    return v, nil
+}
+
+// formatField prints scalars as plain text and anything else as indented
+// JSON, the way --field shows a value.
+func formatField(v interface{}) string {
+    switch v := v.(type) {
+    case nil:
+        return ""
+    case string:
+        return v
+    case map[string]interface{}, []interface{}:
+        b, _ := json.MarshalIndent(v, "", "  ")
+        return string(b)
+    default:
+        b, _ := json.Marshal(v)
+        return string(b)
+    }
+}
+
+// flattenJSON maps every leaf of v to its path, e.g.
+// "Config.Env[0]" => "PATH=/usr/bin". Empty objects and arrays count as
+// leaves so that they still show up in a diff.
+func flattenJSON(prefix string, v interface{}, out map[string]string) {
+    switch node := v.(type) {
+    case map[string]interface{}:
+        if len(node) == 0 {
+            out[prefix] = "{}"
+            return
+        }
+        for k, child := range node {
+            flattenJSON(joinPath(prefix, k), child, out)
+        }
+
+    case []interface{}:
+        if len(node) == 0 {
+            out[prefix] = "[]"
+            return
+        }
+        for i, child := range node {
+            flattenJSON(fmt.Sprintf("%s[%d]", prefix, i), child, out)
+        }
+
+    case nil:
+        out[prefix] = "null"
+
+    default:
+        out[prefix] = formatField(node)
+    }
+}
+
+// joinPath appends key to a path, quoting keys that would not parse back
+// as a plain segment (e.g. labels with dots in them).
+func joinPath(prefix, key string) string {
+    if key == "" || strings.ContainsAny(key, ".[]\"") {
+        return prefix + "[" + strconv.Quote(key) + "]"
+    }
+    if prefix == "" {
+        return key
+    }
+    return prefix + "." + key
+}
+
+func sortedKeys(m map[string]string) []string {
+    keys := make([]string, 0, len(m))
+    for k := range m {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+    return keys
+}
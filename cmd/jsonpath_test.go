@@ -0,0 +1,124 @@
+package cmd
+
+import (
+    "encoding/json"
+    "testing"
+)
+
+const inspectFixture = `{
+  "Name": "/web",
+  "State": {"Status": "running", "Health": {"Status": "healthy"}},
+  "Config": {
+    "Env": ["PATH=/usr/bin", "DEBUG=1"],
+    "Labels": {"com.docker.compose.service": "web", "tier": "frontend"}
+  },
+  "Mounts": []
+}`
+
+func decodeFixture(t *testing.T) interface{} {
+    t.Helper()
+    var doc interface{}
+    if err := json.Unmarshal([]byte(inspectFixture), &doc); err != nil {
+        t.Fatal(err)
+    }
+    return doc
+}
+
+func TestLookupField(t *testing.T) {
+    doc := decodeFixture(t)
+
+    tests := []struct {
+        path string
+        want string
+        ok   bool
+    }{
+        {".State.Health.Status", "healthy", true},
+        {"State.Status", "running", true},
+        {"$.Config.Env[1]", "DEBUG=1", true},
+        {"state.status", "running", true},
+        {`Config.Labels["com.docker.compose.service"]`, "web", true},
+        {"Config.Labels.tier", "frontend", true},
+        {"Mounts", "[]", true},
+        {"Config.Env[2]", "", false},
+        {"Config.Env.first", "", false},
+        {"State.Status[0]", "", false},
+        {"Missing", "", false},
+    }
+    for _, tt := range tests {
+        segments, err := parseFieldPath(tt.path)
+        if err != nil {
+            t.Errorf("%s: %v", tt.path, err)
+            continue
+        }
+        v, err := lookupField(doc, segments)
+        if ok := err == nil; ok != tt.ok || (ok && formatField(v) != tt.want) {
+            t.Errorf("%s: got %q, %v; want %q, found %v", tt.path, formatField(v), err, tt.want, tt.ok)
+        }
+    }
+}
+
+func TestLookupFieldAmbiguousCase(t *testing.T) {
+    var doc interface{}
+    if err := json.Unmarshal([]byte(`{"Labels": {"Env": "a", "ENV": "b", "env": "c"}}`), &doc); err != nil {
+        t.Fatal(err)
+    }
+
+    // An exact match wins over the others
+    if v, err := lookupField(doc, []pathSegment{{key: "Labels"}, {key: "env"}}); err != nil || v != "c" {
+        t.Errorf("got %v, %v; want c", v, err)
+    }
+    // Otherwise the same error every time, whatever the map order
+    for i := 0; i < 20; i++ {
+        _, err := lookupField(doc, []pathSegment{{key: "Labels"}, {key: "eNv"}})
+        if err == nil || err.Error() != "eNv is ambiguous: it matches ENV, Env, env" {
+            t.Fatalf("got %v, want an ambiguity error", err)
+        }
+    }
+}
+
+func TestParseFieldPathErrors(t *testing.T) {
+    for _, path := range []string{"Config.Env[0", "Config.Env[x]", "Config.Env[-1]"} {
+        if _, err := parseFieldPath(path); err == nil {
+            t.Errorf("%s: want an error", path)
+        }
+    }
+}
+
+func TestParseFieldPathWholeDocument(t *testing.T) {
+    for _, path := range []string{"$", ".", ""} {
+        segments, err := parseFieldPath(path)
+        if err != nil || len(segments) != 0 {
+            t.Errorf("%q: got %v, %v; want no segments", path, segments, err)
+        }
+    }
+}
+
+func TestFlattenJSONRoundTrips(t *testing.T) {
+    doc := decodeFixture(t)
+    flat := make(map[string]string)
+    flattenJSON("", doc, flat)
+
+    want := map[string]string{
+        "Name":                "/web",
+        "State.Health.Status": "healthy",
+        "Config.Env[0]":       "PATH=/usr/bin",
+        "Config.Labels.tier":  "frontend",
+        `Config.Labels["com.docker.compose.service"]`: "web",
+        "Mounts": "[]",
+    }
+    for path, value := range want {
+        if flat[path] != value {
+            t.Errorf("%s = %q, want %q", path, flat[path], value)
+        }
+
+        // Every flattened path selects its value again
+        segments, err := parseFieldPath(path)
+        if err != nil {
+            t.Errorf("%s: %v", path, err)
+            continue
+        }
+        if v, err := lookupField(doc, segments); err != nil || (value != "[]" && formatField(v) != value) {
+            t.Errorf("%s does not select %q again", path, value)
+        }
+    }
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+    "docker-manager/internal/docker"
+
+    "github.com/spf13/cobra"
+)
+
+var killSignal string
+
+var killCmd = &cobra.Command{
+    Use:   "kill container [container...]",
+    Short: "Send a signal to containers",
+    Long:  `Send a signal to the main process of one or more running containers. The default is SIGKILL.`,
+    Args:  cobra.MinimumNArgs(1),
+    Run: func(cmd *cobra.Command, args []string) {
+        runEach(cmd, args, "killing", func(runtime docker.ContainerRuntime, id string) error {
+            return runtime.KillContainer(id, killSignal)
+        })
+    },
+}
+
+func init() {
+    killCmd.Flags().StringVarP(&killSignal, "signal", "s", "SIGKILL", "Signal to send, by name (SIGTERM) or number (15)")
+}
@@ -2,52 +2,105 @@ package cmd
 
 import (
     "fmt"
+    "io"
     "os"
+    "strings"
     "text/tabwriter"
+    "time"
 
     "docker-manager/internal/docker"
 
     "github.com/spf13/cobra"
 )
 
-var listAll bool
+var (
+    listAll    bool
+    listStats  bool
+    listFormat string
+    listFilter []string
+)
+
+// listSampleInterval separates the two stats samples list --stats takes to
+// work out rates.
+const listSampleInterval = time.Second
 
 var listCmd = &cobra.Command{
     Use:   "list",
     Short: "List Docker containers",
-    Long:  `List all Docker containers in a static table format.`,
+    Long:  `List all Docker containers as a table or in a structured format for scripting.`,
     Run: func(cmd *cobra.Command, args []string) {
-        dockerClient, err := docker.NewDockerClient()
+        runtime, err := newRuntime()
         if err != nil {
             fmt.Printf("Error connecting to Docker: %v\n", err)
             os.Exit(1)
         }
 
-        containers, err := dockerClient.ListContainers(listAll)
+        opts := docker.ListOptions{
+            All:       listAll,
+            SkipStats: !listStats,
+            Filters:   parseFilterFlags(listFilter),
+        }
+        if listStats {
+            // Rates need two samples, so the first listing only takes one
+            if _, err := runtime.ListContainers(opts); err != nil {
+                fmt.Printf("Error listing containers: %v\n", err)
+                os.Exit(1)
+            }
+            time.Sleep(listSampleInterval)
+        }
+
+        containers, err := runtime.ListContainers(opts)
         if err != nil {
             fmt.Printf("Error listing containers: %v\n", err)
             os.Exit(1)
         }
 
-        w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-        fmt.Fprintln(w, "ID\tNAME\tIMAGE\tSTATUS\tPORTS\tCPU%\tMEMORY%\tNETWORK\tUPTIME")
+        if err := renderContainers(cmd.OutOrStdout(), listFormat, containers, listTable); err != nil {
+            fmt.Printf("Error formatting output: %v\n", err)
+            os.Exit(1)
+        }
+    },
+}
+
+func listTable(out io.Writer, containers []docker.ContainerInfo) {
+    w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+    if listStats {
+        fmt.Fprintln(w, "ID\tNAME\tIMAGE\tSTATUS\tPORTS\tCPU%\tMEMORY%\tNETWORK\tNET TOTAL\tUPTIME")
+    } else {
+        fmt.Fprintln(w, "ID\tNAME\tIMAGE\tSTATUS\tPORTS\tUPTIME")
+    }
 
-        for _, c := range containers {
-            uptime := time.Since(c.Created).Truncate(time.Second).String()
-            status := c.Status
-            if strings.Contains(status, "Up") {
-                status = fmt.Sprintf("\033[32m%s\033[0m", status) // Green
-            } else if strings.Contains(status, "Exited") {
-                status = fmt.Sprintf("\033[31m%s\033[0m", status) // Red
-            }
+    for _, c := range containers {
+        uptime := time.Since(c.Created).Truncate(time.Second).String()
+        status := c.Status
+        if strings.Contains(status, "Up") {
+            status = colorize(colorGreen, status)
+        } else if strings.Contains(status, "Exited") {
+            status = colorize(colorRed, status)
+        }
 
-            fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%.1f\t%.1f\t%s\t%s\n",
-                c.ID, c.Name, c.Image, status, c.Ports, c.CPU, c.Memory, c.Network, uptime)
+        if !listStats {
+            fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+                c.ID, c.Name, c.Image, status, c.Ports, uptime)
+            continue
         }
-        w.Flush()
-    },
+
+        cpu, mem, network, netTotal := "-", "-", "-", "-"
+        if c.HasStats {
+            cpu = fmt.Sprintf("%.1f", c.CPU)
+            mem = fmt.Sprintf("%.1f", c.Memory)
+            network = c.Network
+            netTotal = docker.FormatBytes(c.NetRx) + " / " + docker.FormatBytes(c.NetTx)
+        }
+        fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+            c.ID, c.Name, c.Image, status, c.Ports, cpu, mem, network, netTotal, uptime)
+    }
+    w.Flush()
 }
 
 func init() {
     listCmd.Flags().BoolVarP(&listAll, "all", "a", false, "Show all containers (default shows just running)")
+    listCmd.Flags().BoolVar(&listStats, "stats", false, "Include CPU, memory and network columns (slower: rates take two samples a second apart)")
+    listCmd.Flags().StringVar(&listFormat, "format", "table", formatHelp)
+    listCmd.Flags().StringArrayVarP(&listFilter, "filter", "f", nil, filterHelp)
 }
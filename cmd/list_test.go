@@ -0,0 +1,109 @@
+package cmd
+
+import (
+    "encoding/json"
+    "strings"
+    "testing"
+
+    "docker-manager/internal/docker"
+)
+
+func TestListShowsRunningContainers(t *testing.T) {
+    out := runCommand(t, newTestRuntime(), "list")
+
+    lines := strings.Split(strings.TrimSpace(out), "\n")
+    if len(lines) != 3 {
+        t.Fatalf("want a header and 2 rows, got:\n%s", out)
+    }
+    if !strings.HasPrefix(lines[0], "ID") {
+        t.Errorf("header = %q", lines[0])
+    }
+    for _, name := range []string{"web", "db"} {
+        if !strings.Contains(out, name) {
+            t.Errorf("%s missing from:\n%s", name, out)
+        }
+    }
+    if strings.Contains(out, "job") {
+        t.Errorf("stopped container listed without --all:\n%s", out)
+    }
+}
+
+func TestListAll(t *testing.T) {
+    out := runCommand(t, newTestRuntime(), "list", "--all")
+    if !strings.Contains(out, "job") {
+        t.Errorf("stopped container missing with --all:\n%s", out)
+    }
+}
+
+func TestListStats(t *testing.T) {
+    out := runCommand(t, newTestRuntime(), "list")
+    if strings.Contains(out, "CPU%") {
+        t.Errorf("stats columns shown without --stats:\n%s", out)
+    }
+
+    fake := newTestRuntime()
+    out = runCommand(t, fake, "list", "--stats")
+    for _, want := range []string{"NETWORK", "NET TOTAL", "↓2.0KiB/s ↑512B/s", "3.0MiB / 1.0MiB"} {
+        if !strings.Contains(out, want) {
+            t.Errorf("%q missing from:\n%s", want, out)
+        }
+    }
+    for _, line := range strings.Split(out, "\n") {
+        switch {
+        case strings.HasPrefix(line, "ID") && !strings.Contains(line, "CPU%"):
+            t.Errorf("header without stats columns: %q", line)
+        case strings.Contains(line, "web") && !strings.Contains(line, "12.5"):
+            t.Errorf("web row without its CPU: %q", line)
+        case strings.Contains(line, "db") && !strings.Contains(line, " - "):
+            t.Errorf("db has no stats but its row shows some: %q", line)
+        }
+    }
+
+    // Rates need a second sample
+    listed := 0
+    for _, call := range fake.Calls() {
+        if strings.HasPrefix(call, "ListContainers(") {
+            listed++
+        }
+    }
+    if listed != 2 {
+        t.Errorf("listed containers %d times, want 2", listed)
+    }
+}
+
+func TestListJSON(t *testing.T) {
+    out := runCommand(t, newTestRuntime(), "list", "--all", "--format", "json")
+
+    var containers []docker.ContainerInfo
+    if err := json.Unmarshal([]byte(out), &containers); err != nil {
+        t.Fatalf("invalid JSON: %v\n%s", err, out)
+    }
+    var names []string
+    for _, c := range containers {
+        names = append(names, c.Name)
+    }
+    if got := strings.Join(names, ","); got != "web,db,job" {
+        t.Errorf("names = %s, want web,db,job", got)
+    }
+}
+
+func TestListEmptyJSONIsArray(t *testing.T) {
+    out := runCommand(t, docker.NewFakeRuntime(), "list", "--format", "json")
+    if got := strings.TrimSpace(out); got != "[]" {
+        t.Errorf("got %q, want []", got)
+    }
+}
+
+func TestListTemplate(t *testing.T) {
+    out := runCommand(t, newTestRuntime(), "list", "--format", "{{.Name}} {{.Health}}")
+    if got := strings.TrimSpace(out); got != "web \ndb healthy" {
+        t.Errorf("got %q", got)
+    }
+}
+
+func TestListFilter(t *testing.T) {
+    out := runCommand(t, newTestRuntime(), "list", "--all", "--format", "{{.Name}}", "--filter", "label=tier=backend")
+    if got := strings.TrimSpace(out); got != "db" {
+        t.Errorf("got %q, want db", got)
+    }
+}
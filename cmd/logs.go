@@ -1,40 +1,262 @@
 package cmd
 
 import (
+    "context"
     "fmt"
+    "io"
     "os"
+    "os/signal"
+    "strconv"
+    "strings"
+    "syscall"
 
     "docker-manager/internal/docker"
 
     "github.com/spf13/cobra"
 )
 
-var tailLines int
+var (
+    tailLines      int
+    logsFollow     bool
+    logsSince      string
+    logsUntil      string
+    logsTimestamps bool
+    logsStderrOnly bool
+    logsLabels     []string
+    logsFilter     []string
+    logsProject    string
+
+    logsOutput       string
+    logsOutputFormat string
+    logsGzip         bool
+)
 
 var logsCmd = &cobra.Command{
-    Use:   "logs [container]",
+    Use:   "logs [container...]",
     Short: "Show container logs",
-    Long:  `Display logs for a specific container.`,
-    Args:  cobra.ExactArgs(1),
+    Long: `Display logs for one or more containers, optionally following new output until Ctrl+C.
+
+With several containers, --label, --filter or --project the logs are merged
+in timestamp order and each line is prefixed with its container's name.
+
+--output saves the logs to a file, as plain text or as JSON lines with the
+stream and timestamp of every line.`,
     Run: func(cmd *cobra.Command, args []string) {
-        containerID := args[0]
+        selectors := logSelectors()
+        if len(args) == 0 && len(selectors) == 0 {
+            fmt.Println("Error: specify a container, --label, --filter or --project")
+            os.Exit(1)
+        }
 
-        dockerClient, err := docker.NewDockerClient()
+        runtime, err := newRuntime()
         if err != nil {
             fmt.Printf("Error connecting to Docker: %v\n", err)
             os.Exit(1)
         }
 
-        logs, err := dockerClient.GetContainerLogs(containerID)
+        opts := docker.LogOptions{
+            Tail:       "all",
+            Since:      logsSince,
+            Until:      logsUntil,
+            Timestamps: logsTimestamps,
+            Follow:     logsFollow,
+            Stderr:     logsStderrOnly,
+        }
+        if tailLines >= 0 {
+            opts.Tail = strconv.Itoa(tailLines)
+        }
+
+        containers := logContainers(runtime, args, selectors)
+        if len(containers) == 0 {
+            fmt.Println("Error: no containers match")
+            os.Exit(1)
+        }
+
+        if logsOutput != "" {
+            exportLogs(runtime, containers, opts)
+            return
+        }
+
+        out := cmd.OutOrStdout()
+        prefix := logPrefixes(containers)
+        err = fetchLogs(runtime, containers, opts, func(line docker.LogLine) error {
+            fmt.Fprint(out, prefix[line.Container])
+            printLogLine(out, line)
+            return nil
+        })
         if err != nil {
             fmt.Printf("Error getting logs: %v\n", err)
             os.Exit(1)
         }
-
-        fmt.Println(logs)
     },
 }
 
+// exportLogs saves the logs to --output instead of printing them.
+func exportLogs(runtime docker.ContainerRuntime, containers []docker.ContainerInfo, opts docker.LogOptions) {
+    format := logsOutputFormat
+    if format == "" {
+        format = docker.ExportFormatFor(logsOutput)
+    }
+    exporter, err := docker.CreateLogExport(logsOutput, docker.ExportOptions{
+        Format:     format,
+        Gzip:       logsGzip,
+        Timestamps: logsTimestamps,
+    })
+    if err != nil {
+        fmt.Printf("Error creating %s: %v\n", logsOutput, err)
+        os.Exit(1)
+    }
+
+    err = fetchLogs(runtime, containers, opts, exporter.Write)
+    if closeErr := exporter.Close(); err == nil {
+        err = closeErr
+    }
+    if err != nil {
+        fmt.Printf("Error exporting logs: %v\n", err)
+        os.Exit(1)
+    }
+    fmt.Printf("Saved %d lines to %s\n", exporter.Count(), logsOutput)
+}
+
+// fetchLogs passes every line to write, merging several containers by
+// timestamp. With Follow set it runs until the logs end or Ctrl+C.
+func fetchLogs(runtime docker.ContainerRuntime, containers []docker.ContainerInfo, opts docker.LogOptions, write func(docker.LogLine) error) error {
+    if !opts.Follow {
+        var lines []docker.LogLine
+        var err error
+        if len(containers) == 1 {
+            lines, err = runtime.GetContainerLogs(containers[0].ID, opts)
+        } else {
+            lines, err = docker.CollectLogs(runtime, containers, opts)
+        }
+        if err != nil {
+            return err
+        }
+
+        for _, line := range lines {
+            if err := write(line); err != nil {
+                return err
+            }
+        }
+        return nil
+    }
+
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
+
+    var stream *docker.LogStream
+    var err error
+    if len(containers) == 1 {
+        stream, err = runtime.StreamLogs(ctx, containers[0].ID, opts)
+    } else {
+        stream, err = docker.MergeLogs(ctx, runtime, containers, opts)
+    }
+    if err != nil {
+        return err
+    }
+    defer stream.Close()
+
+    for line := range stream.Lines {
+        if err := write(line); err != nil {
+            return err
+        }
+    }
+    return stream.Err()
+}
+
+// logSelectors gathers --label, --filter and --project into filters.
+func logSelectors() []docker.Filter {
+    filters := parseFilterFlags(logsFilter)
+    for _, label := range logsLabels {
+        filters = append(filters, docker.Filter{Key: "label", Value: label})
+    }
+    if logsProject != "" {
+        filters = append(filters, docker.Filter{Key: "project", Value: logsProject})
+    }
+    return filters
+}
+
+// logContainers resolves the named containers and those matching the
+// selectors, without duplicates. Names are inspected first since they may
+// be a name, a full ID or an ID prefix of a container a selector matches.
+func logContainers(runtime docker.ContainerRuntime, names []string, selectors []docker.Filter) []docker.ContainerInfo {
+    var containers []docker.ContainerInfo
+    seen := make(map[string]bool)
+
+    for _, name := range names {
+        details, err := runtime.InspectContainer(name)
+        if err != nil {
+            fmt.Printf("Error inspecting %s: %v\n", name, err)
+            os.Exit(1)
+        }
+        // Listed containers have short IDs
+        id := details.ID
+        if len(id) > 12 {
+            id = id[:12]
+        }
+        if !seen[id] {
+            seen[id] = true
+            containers = append(containers, docker.ContainerInfo{ID: id, Name: details.Name})
+        }
+    }
+
+    if len(selectors) > 0 {
+        matched, err := runtime.ListContainers(docker.ListOptions{All: true, SkipStats: true, Filters: selectors})
+        if err != nil {
+            fmt.Printf("Error listing containers: %v\n", err)
+            os.Exit(1)
+        }
+        for _, c := range matched {
+            if !seen[c.ID] {
+                seen[c.ID] = true
+                containers = append(containers, c)
+            }
+        }
+    }
+    return containers
+}
+
+// logPrefixes builds the padded "name | " prefix of each container, like
+// docker compose logs. A single container needs none.
+func logPrefixes(containers []docker.ContainerInfo) map[string]string {
+    if len(containers) < 2 {
+        return nil
+    }
+
+    width := 0
+    for _, c := range containers {
+        if len(c.Name) > width {
+            width = len(c.Name)
+        }
+    }
+
+    prefixes := make(map[string]string, len(containers))
+    for i, c := range containers {
+        name := c.Name + strings.Repeat(" ", width-len(c.Name))
+        prefixes[c.Name] = colorize(prefixColors[i%len(prefixColors)], name+" | ")
+    }
+    return prefixes
+}
+
+func printLogLine(out io.Writer, line docker.LogLine) {
+    text := line.Format(logsTimestamps)
+    if line.Stream == docker.StreamStderr {
+        text = colorize(colorRed, text)
+    }
+    fmt.Fprintln(out, text)
+}
+
 func init() {
-    logsCmd.Flags().IntVarP(&tailLines, "tail", "t", 100, "Number of lines to show from the end of the logs")
+    logsCmd.Flags().IntVarP(&tailLines, "tail", "t", 100, "Number of lines to show from the end of the logs (-1 for all)")
+    logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Follow log output until Ctrl+C")
+    logsCmd.Flags().StringVar(&logsSince, "since", "", "Show logs since a timestamp (e.g. 2024-01-02T13:23:37Z) or relative duration (e.g. 42m)")
+    logsCmd.Flags().StringVar(&logsUntil, "until", "", "Show logs before a timestamp or relative duration")
+    logsCmd.Flags().BoolVar(&logsTimestamps, "timestamps", false, "Show timestamps")
+    logsCmd.Flags().BoolVar(&logsStderrOnly, "stderr-only", false, "Only show the stderr stream")
+    logsCmd.Flags().StringArrayVarP(&logsLabels, "label", "l", nil, "Merge the logs of containers with this label (key or key=value); repeatable")
+    logsCmd.Flags().StringArrayVar(&logsFilter, "filter", nil, filterHelp)
+    logsCmd.Flags().StringVar(&logsProject, "project", "", "Merge the logs of a compose project's containers")
+    logsCmd.Flags().StringVarP(&logsOutput, "output", "o", "", "Save the logs to a file instead of printing them")
+    logsCmd.Flags().StringVar(&logsOutputFormat, "output-format", "", "File format: text or jsonl (default guessed from the file name)")
+    logsCmd.Flags().BoolVar(&logsGzip, "gzip", false, "Compress the output file (implied by a .gz file name)")
 }
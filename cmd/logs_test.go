@@ -0,0 +1,28 @@
+package cmd
+
+import (
+    "strings"
+    "testing"
+    "time"
+
+    "docker-manager/internal/docker"
+)
+
+func TestLogsDoesNotRepeatAContainer(t *testing.T) {
+    tests := [][]string{
+        {"logs", "aaaa", "--label", "tier=frontend"},
+        {"logs", "web", "aaaaaaaaaaaa"},
+        {"logs", "web", "--filter", "name=web"},
+    }
+    for _, args := range tests {
+        fake := newTestRuntime()
+        fake.Logs["aaaaaaaaaaaa"] = []docker.LogLine{
+            {Stream: docker.StreamStdout, Timestamp: time.Now(), Text: "GET / 200"},
+        }
+
+        out := runCommand(t, fake, args...)
+        if got := strings.Count(out, "GET / 200"); got != 1 {
+            t.Errorf("%v: line printed %d times, want once:\n%s", args, got, out)
+        }
+    }
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+    "docker-manager/internal/docker"
+
+    "github.com/spf13/cobra"
+)
+
+var pauseCmd = &cobra.Command{
+    Use:   "pause container [container...]",
+    Short: "Pause all processes within containers",
+    Args:  cobra.MinimumNArgs(1),
+    Run: func(cmd *cobra.Command, args []string) {
+        runEach(cmd, args, "pausing", func(runtime docker.ContainerRuntime, id string) error {
+            return runtime.PauseContainer(id)
+        })
+    },
+}
+
+var unpauseCmd = &cobra.Command{
+    Use:   "unpause container [container...]",
+    Short: "Unpause all processes within containers",
+    Args:  cobra.MinimumNArgs(1),
+    Run: func(cmd *cobra.Command, args []string) {
+        runEach(cmd, args, "unpausing", func(runtime docker.ContainerRuntime, id string) error {
+            return runtime.UnpauseContainer(id)
+        })
+    },
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+    "fmt"
+    "os"
+
+    "github.com/spf13/cobra"
+)
+
+var renameCmd = &cobra.Command{
+    Use:   "rename container new-name",
+    Short: "Rename a container",
+    Args:  cobra.ExactArgs(2),
+    Run: func(cmd *cobra.Command, args []string) {
+        runtime, err := newRuntime()
+        if err != nil {
+            fmt.Printf("Error connecting to Docker: %v\n", err)
+            os.Exit(1)
+        }
+
+        if err := runtime.RenameContainer(args[0], args[1]); err != nil {
+            fmt.Printf("Error renaming %s: %v\n", args[0], err)
+            os.Exit(1)
+        }
+        // Like the other actions, print what was acted on
+        fmt.Fprintln(cmd.OutOrStdout(), args[1])
+    },
+}
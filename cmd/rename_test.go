@@ -0,0 +1,15 @@
+package cmd
+
+import "testing"
+
+func TestRenamePrintsNewName(t *testing.T) {
+    fake := newTestRuntime()
+
+    out := runCommand(t, fake, "rename", "web", "frontend")
+    if out != "frontend\n" {
+        t.Errorf("got %q, want the new name", out)
+    }
+    if name := fake.Containers[0].Name; name != "frontend" {
+        t.Errorf("container is named %s, want frontend", name)
+    }
+}
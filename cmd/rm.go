@@ -0,0 +1,36 @@
+package cmd
+
+import (
+    "docker-manager/internal/docker"
+
+    "github.com/spf13/cobra"
+)
+
+var (
+    rmForce   bool
+    rmVolumes bool
+    rmLinks   bool
+)
+
+var rmCmd = &cobra.Command{
+    Use:   "rm container [container...]",
+    Short: "Remove containers",
+    Long:  `Remove one or more containers. Running containers are only removed with --force.`,
+    Args:  cobra.MinimumNArgs(1),
+    Run: func(cmd *cobra.Command, args []string) {
+        opts := docker.RemoveOptions{
+            Force:         rmForce,
+            RemoveVolumes: rmVolumes,
+            RemoveLinks:   rmLinks,
+        }
+        runEach(cmd, args, "removing", func(runtime docker.ContainerRuntime, id string) error {
+            return runtime.RemoveContainer(id, opts)
+        })
+    },
+}
+
+func init() {
+    rmCmd.Flags().BoolVarP(&rmForce, "force", "f", false, "Force the removal of a running container")
+    rmCmd.Flags().BoolVarP(&rmVolumes, "volumes", "v", false, "Remove anonymous volumes associated with the container")
+    rmCmd.Flags().BoolVarP(&rmLinks, "link", "l", false, "Remove the specified link instead of the container")
+}
@@ -4,6 +4,8 @@ import (
     "fmt"
     "os"
 
+    "docker-manager/internal/docker"
+
     "github.com/spf13/cobra"
 )
 
@@ -11,6 +13,19 @@ var rootCmd = &cobra.Command{
     Use:   "docker-manager",
     Short: "A terminal-based Docker container manager",
     Long:  `A feature-rich TUI for managing Docker containers with real-time monitoring and controls.`,
+    PersistentPreRun: func(cmd *cobra.Command, args []string) {
+        setupColor(cmd.OutOrStdout())
+    },
+}
+
+// newRuntime connects to the container runtime used by every command.
+// Tests swap it out for a docker.FakeRuntime.
+var newRuntime = func() (docker.ContainerRuntime, error) {
+    dockerClient, err := docker.NewDockerClient()
+    if err != nil {
+        return nil, err
+    }
+    return dockerClient, nil
 }
 
 func Execute() {
@@ -21,8 +36,19 @@ func Execute() {
 }
 
 func init() {
+    rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+
     rootCmd.AddCommand(listCmd)
     rootCmd.AddCommand(statsCmd)
     rootCmd.AddCommand(logsCmd)
+    rootCmd.AddCommand(stopCmd)
+    rootCmd.AddCommand(restartCmd)
+    rootCmd.AddCommand(rmCmd)
+    rootCmd.AddCommand(pauseCmd)
+    rootCmd.AddCommand(unpauseCmd)
+    rootCmd.AddCommand(killCmd)
+    rootCmd.AddCommand(renameCmd)
+    rootCmd.AddCommand(inspectCmd)
+    rootCmd.AddCommand(execCmd)
     rootCmd.AddCommand(interactiveCmd)
 }
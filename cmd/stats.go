@@ -1,7 +1,9 @@
 package cmd
 
 import (
+    "context"
     "fmt"
+    "io"
     "os"
     "text/tabwriter"
     "time"
@@ -11,66 +13,113 @@ import (
     "github.com/spf13/cobra"
 )
 
+var (
+    statsFormat   string
+    statsNoStream bool
+    statsFilter   []string
+)
+
 var statsCmd = &cobra.Command{
     Use:   "stats",
     Short: "Show real-time container statistics",
     Long:  `Display real-time CPU, memory, and network statistics for all containers.`,
     Run: func(cmd *cobra.Command, args []string) {
-        dockerClient, err := docker.NewDockerClient()
+        runtime, err := newRuntime()
         if err != nil {
             fmt.Printf("Error connecting to Docker: %v\n", err)
             os.Exit(1)
         }
 
+        filters := parseFilterFlags(statsFilter)
+
+        ctx, cancel := context.WithCancel(context.Background())
+        defer cancel()
+        source := runtime.WatchStats(ctx)
+
+        out := cmd.OutOrStdout()
         ticker := time.NewTicker(2 * time.Second)
         defer ticker.Stop()
 
+        // Give the collector time to take two samples so rates are known
+        if statsNoStream {
+            <-ticker.C
+        }
+
         for {
-            containers, err := dockerClient.ListContainers(true)
+            containers, err := runtime.ListContainers(docker.ListOptions{
+                All:       true,
+                SkipStats: true,
+                Filters:   filters,
+            })
             if err != nil {
                 fmt.Printf("Error listing containers: %v\n", err)
                 os.Exit(1)
             }
+            docker.ApplyStats(containers, source.Snapshot())
 
             // Clear screen and move cursor to top
-            fmt.Print("\033[H\033[2J")
-
-            w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-            fmt.Fprintln(w, "ID\tNAME\tCPU%\tMEMORY%\tNETWORK I/O\tSTATUS")
-
-            totalCPU, totalMemory := 0.0, 0.0
-            for _, c := range containers {
-                cpuStyle, memStyle := "", ""
-                if c.CPU > 80 {
-                    cpuStyle = "\033[31m" // Red
-                } else if c.CPU > 60 {
-                    cpuStyle = "\033[33m" // Yellow
-                } else {
-                    cpuStyle = "\033[32m" // Green
-                }
-
-                if c.Memory > 80 {
-                    memStyle = "\033[31m" // Red
-                } else if c.Memory > 60 {
-                    memStyle = "\033[33m" // Yellow
-                } else {
-                    memStyle = "\033[32m" // Green
-                }
-
-                fmt.Fprintf(w, "%s\t%s\t%s%.1f%%\033[0m\t%s%.1f%%\033[0m\t%s\t%s\n",
-                    c.ID[:12], c.Name, cpuStyle, c.CPU, memStyle, c.Memory, c.Network, c.Status)
-
-                totalCPU += c.CPU
-                totalMemory += c.Memory
+            redraw := isTableFormat(statsFormat) && !statsNoStream && isTerminal(out)
+            if redraw {
+                fmt.Fprint(out, "\033[H\033[2J")
             }
 
-            fmt.Fprintf(w, "\n%s\t%s\t%.1f%%\t%.1f%%\t%s\t%s\n",
-                "TOTAL", "", totalCPU, totalMemory, "", fmt.Sprintf("%d containers", len(containers)))
+            if err := renderContainers(out, statsFormat, containers, statsTable); err != nil {
+                fmt.Printf("Error formatting output: %v\n", err)
+                os.Exit(1)
+            }
 
-            w.Flush()
-            fmt.Printf("\nRefreshing every 2 seconds. Press Ctrl+C to stop...")
+            if statsNoStream {
+                return
+            }
+            if redraw {
+                fmt.Fprintf(out, "\nRefreshing every 2 seconds. Press Ctrl+C to stop...")
+            }
 
             <-ticker.C
         }
     },
 }
+
+func statsTable(out io.Writer, containers []docker.ContainerInfo) {
+    w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+    fmt.Fprintln(w, "ID\tNAME\tCPU%\tMEM USAGE / LIMIT\tMEMORY%\tNET RATE\tNET TOTAL\tBLOCK RATE\tBLOCK TOTAL\tPIDS\tSTATUS")
+
+    totalCPU, totalMemory := 0.0, 0.0
+    var totalMemUsage uint64
+    for _, c := range containers {
+        memUsage, netTotal, blockTotal, pids := "-", "-", "-", "-"
+        if c.HasStats {
+            memUsage = docker.FormatBytes(c.MemUsage) + " / " + docker.FormatBytes(c.MemLimit)
+            netTotal = docker.FormatBytes(c.NetRx) + " / " + docker.FormatBytes(c.NetTx)
+            blockTotal = docker.FormatBytes(c.BlockRead) + " / " + docker.FormatBytes(c.BlockWrite)
+            pids = docker.FormatPIDs(c.PIDs, c.PIDsLimit)
+        }
+        netRate, blockRate := "-", "-"
+        if c.HasRates {
+            netRate = c.Network
+            blockRate = docker.FormatRate(c.BlockReadRate) + " / " + docker.FormatRate(c.BlockWriteRate)
+        }
+
+        fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+            c.ID, c.Name,
+            colorize(usageColor(c.CPU), fmt.Sprintf("%.1f%%", c.CPU)),
+            memUsage,
+            colorize(usageColor(c.Memory), fmt.Sprintf("%.1f%%", c.Memory)),
+            netRate, netTotal, blockRate, blockTotal, pids, c.Status)
+
+        totalCPU += c.CPU
+        totalMemory += c.Memory
+        totalMemUsage += c.MemUsage
+    }
+
+    fmt.Fprintf(w, "\n%s\t%s\t%.1f%%\t%s\t%.1f%%\t%s\t%s\t%s\t%s\t%s\t%s\n",
+        "TOTAL", "", totalCPU, docker.FormatBytes(totalMemUsage), totalMemory, "", "", "", "", "", fmt.Sprintf("%d containers", len(containers)))
+
+    w.Flush()
+}
+
+func init() {
+    statsCmd.Flags().StringVar(&statsFormat, "format", "table", formatHelp)
+    statsCmd.Flags().StringArrayVarP(&statsFilter, "filter", "f", nil, filterHelp)
+    statsCmd.Flags().BoolVar(&statsNoStream, "no-stream", false, "Print a single snapshot and exit")
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+    "encoding/json"
+    "strings"
+    "testing"
+
+    "docker-manager/internal/docker"
+)
+
+func TestStatsSnapshot(t *testing.T) {
+    out := runCommand(t, newTestRuntime(), "stats", "--no-stream")
+
+    var web string
+    for _, line := range strings.Split(out, "\n") {
+        if strings.Contains(line, "web") {
+            web = line
+        }
+    }
+    for _, want := range []string{"12.5%", "256.0MiB / 1.0GiB", "↓2.0KiB/s ↑512B/s", "3.0MiB / 1.0MiB"} {
+        if !strings.Contains(web, want) {
+            t.Errorf("%q missing from the web row %q", want, web)
+        }
+    }
+}
+
+func TestStatsJSON(t *testing.T) {
+    out := runCommand(t, newTestRuntime(), "stats", "--no-stream", "--format", "json")
+
+    var containers []docker.ContainerInfo
+    if err := json.Unmarshal([]byte(out), &containers); err != nil {
+        t.Fatalf("invalid JSON: %v\n%s", err, out)
+    }
+    if len(containers) != 3 || containers[0].Name != "web" {
+        t.Fatalf("got %+v, want every container, web first", containers)
+    }
+    if c := containers[0]; !c.HasStats || c.CPU != 12.5 || c.NetRxRate != 2048 {
+        t.Errorf("stats not applied: %+v", c)
+    }
+}
+
+func TestStatsFilter(t *testing.T) {
+    out := runCommand(t, newTestRuntime(), "stats", "--no-stream", "--format", "{{.Name}}", "--filter", "status=exited")
+    if got := strings.TrimSpace(out); got != "job" {
+        t.Errorf("got %q, want job", got)
+    }
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+    "docker-manager/internal/docker"
+
+    "github.com/spf13/cobra"
+)
+
+var (
+    stopTime   int
+    stopSignal string
+)
+
+var stopCmd = &cobra.Command{
+    Use:   "stop container [container...]",
+    Short: "Stop running containers",
+    Long:  `Stop one or more containers, sending the stop signal first and killing them once the timeout expires.`,
+    Args:  cobra.MinimumNArgs(1),
+    Run: func(cmd *cobra.Command, args []string) {
+        opts := stopOptions(cmd)
+        runEach(cmd, args, "stopping", func(runtime docker.ContainerRuntime, id string) error {
+            return runtime.StopContainer(id, opts)
+        })
+    },
+}
+
+var restartCmd = &cobra.Command{
+    Use:   "restart container [container...]",
+    Short: "Restart containers",
+    Long:  `Restart one or more containers, stopping them the same way as stop first.`,
+    Args:  cobra.MinimumNArgs(1),
+    Run: func(cmd *cobra.Command, args []string) {
+        opts := stopOptions(cmd)
+        runEach(cmd, args, "restarting", func(runtime docker.ContainerRuntime, id string) error {
+            return runtime.RestartContainer(id, opts)
+        })
+    },
+}
+
+// stopOptions only sets the timeout when --time was given, so the
+// container's own stop timeout applies otherwise.
+func stopOptions(cmd *cobra.Command) docker.StopOptions {
+    opts := docker.StopOptions{Signal: stopSignal}
+    if cmd.Flags().Changed("time") {
+        timeout := stopTime
+        opts.Timeout = &timeout
+    }
+    return opts
+}
+
+func init() {
+    for _, c := range []*cobra.Command{stopCmd, restartCmd} {
+        c.Flags().IntVarP(&stopTime, "time", "t", 10, "Seconds to wait before killing the container (-1 waits forever)")
+        c.Flags().StringVarP(&stopSignal, "signal", "s", "", "Signal to stop the container with (default: the container's stop signal)")
+    }
+}
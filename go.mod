@@ -6,8 +6,11 @@ require (
     github.com/charmbracelet/bubbletea v0.25.0
     github.com/charmbracelet/lipgloss v0.9.1
     github.com/docker/docker v24.0.7+incompatible
+    github.com/muesli/cancelreader v0.2.2
     github.com/shirou/gopsutil v3.21.11+incompatible
     github.com/spf13/cobra v1.8.0
+    golang.org/x/term v0.15.0
+    gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -29,7 +32,6 @@ require (
     github.com/moby/term v0.5.0 // indirect
     github.com/morikuni/aec v1.0.0 // indirect
     github.com/muesli/ansi v0.0.0-20230316100256-276c6243b2f6 // indirect
-    github.com/muesli/cancelreader v0.2.2 // indirect
     github.com/muesli/reflow v0.3.0 // indirect
     github.com/muesli/termenv v0.15.2 // indirect
     github.com/opencontainers/go-digest v1.0.0 // indirect
@@ -46,7 +48,6 @@ require (
     golang.org/x/net v0.19.0 // indirect
     golang.org/x/sync v0.5.0 // indirect
     golang.org/x/sys v0.15.0 // indirect
-    golang.org/x/term v0.15.0 // indirect
     golang.org/x/text v0.14.0 // indirect
     golang.org/x/tools v0.16.1 // indirect
 )
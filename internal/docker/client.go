@@ -2,32 +2,136 @@ package docker
 
 import (
     "context"
+    "encoding/json"
     "fmt"
-    "io"
+    "sort"
+    "strings"
+    "sync"
     "time"
 
     "github.com/docker/docker/api/types"
     "github.com/docker/docker/api/types/container"
-    "github.com/docker/docker/api/types/filters"
     "github.com/docker/docker/client"
-    "github.com/shirou/gopsutil/v3/process"
+)
+
+const (
+    defaultStatsWorkers = 8
+    defaultStatsTimeout = 3 * time.Second
 )
 
 type DockerClient struct {
     cli *client.Client
+
+    statsWorkers int
+    statsTimeout time.Duration
+
+    // Last one-shot sample per container, for rates between refreshes
+    prevMu    sync.Mutex
+    prevStats map[string]ContainerStats
 }
 
+// ContainerInfo field tags are the stable names used by the json, yaml and
+// csv output formats.
 type ContainerInfo struct {
-    ID      string
-    Name    string
-    Image   string
-    Status  string
-    State   string
-    Ports   string
-    Created time.Time
-    CPU     float64
-    Memory  float64
-    Network string
+    ID      string    `json:"id" yaml:"id"`
+    Name    string    `json:"name" yaml:"name"`
+    Image   string    `json:"image" yaml:"image"`
+    Status  string    `json:"status" yaml:"status"`
+    State   string    `json:"state" yaml:"state"`
+    Health  string    `json:"health" yaml:"health"`
+    Ports   string    `json:"ports" yaml:"ports"`
+    Created time.Time `json:"created" yaml:"created"`
+
+    Labels       map[string]string `json:"labels" yaml:"labels"`
+    NetworkNames []string          `json:"network_names" yaml:"network_names"`
+
+    CPU     float64   `json:"cpu_percent" yaml:"cpu_percent"`
+    Memory  float64   `json:"memory_percent" yaml:"memory_percent"`
+    Network string    `json:"network" yaml:"network"`
+
+    MemUsage uint64 `json:"memory_usage_bytes" yaml:"memory_usage_bytes"`
+    MemLimit uint64 `json:"memory_limit_bytes" yaml:"memory_limit_bytes"`
+
+    Networks   map[string]NetworkIO `json:"networks" yaml:"networks"`
+    NetRx      uint64               `json:"net_rx_bytes" yaml:"net_rx_bytes"`
+    NetTx      uint64               `json:"net_tx_bytes" yaml:"net_tx_bytes"`
+    BlockRead  uint64               `json:"block_read_bytes" yaml:"block_read_bytes"`
+    BlockWrite uint64               `json:"block_write_bytes" yaml:"block_write_bytes"`
+    PIDs       uint64               `json:"pids" yaml:"pids"`
+    PIDsLimit  uint64               `json:"pids_limit" yaml:"pids_limit"`
+
+    // Per-second rates since the previous sample, in bytes
+    NetRxRate      float64 `json:"net_rx_rate" yaml:"net_rx_rate"`
+    NetTxRate      float64 `json:"net_tx_rate" yaml:"net_tx_rate"`
+    BlockReadRate  float64 `json:"block_read_rate" yaml:"block_read_rate"`
+    BlockWriteRate float64 `json:"block_write_rate" yaml:"block_write_rate"`
+    HasRates       bool    `json:"has_rates" yaml:"has_rates"`
+
+    // HasStats is false when stats were skipped or could not be
+    // collected in time.
+    HasStats bool `json:"has_stats" yaml:"has_stats"`
+}
+
+func (c *ContainerInfo) applyStats(stats *ContainerStats) {
+    c.CPU = stats.CPU
+    c.Memory = stats.Memory
+    c.MemUsage = stats.MemUsage
+    c.MemLimit = stats.MemLimit
+    c.Network = stats.Network
+    c.Networks = stats.Networks
+    c.NetRx = stats.NetRx
+    c.NetTx = stats.NetTx
+    c.BlockRead = stats.BlockRead
+    c.BlockWrite = stats.BlockWrite
+    c.PIDs = stats.PIDs
+    c.PIDsLimit = stats.PIDsLimit
+    c.NetRxRate = stats.NetRxRate
+    c.NetTxRate = stats.NetTxRate
+    c.BlockReadRate = stats.BlockReadRate
+    c.BlockWriteRate = stats.BlockWriteRate
+    c.HasRates = stats.HasRates
+    c.HasStats = true
+}
+
+type ListOptions struct {
+    All bool
+
+    // SkipStats lists containers without querying their stats.
+    SkipStats bool
+
+    // Filters are evaluated by the daemon. Filters with the same key are
+    // ORed, different keys are ANDed.
+    Filters []Filter
+}
+
+type StopOptions struct {
+    // Signal is sent first, e.g. "SIGINT". Empty uses the container's
+    // stop signal, usually SIGTERM.
+    Signal string
+    // Timeout is how many seconds to wait before killing the container,
+    // -1 to wait forever. Nil uses the container's or daemon's default.
+    Timeout *int
+}
+
+// Wait returns how long a stop may take, or 0 if it isn't known.
+func (o StopOptions) Wait() time.Duration {
+    if o.Timeout == nil || *o.Timeout < 0 {
+        return 0
+    }
+    return time.Duration(*o.Timeout) * time.Second
+}
+
+func (o StopOptions) dockerOptions() container.StopOptions {
+    return container.StopOptions{Signal: o.Signal, Timeout: o.Timeout}
+}
+
+type RemoveOptions struct {
+    // Force kills a running container before removing it
+    Force bool
+    // RemoveVolumes also removes the container's anonymous volumes
+    RemoveVolumes bool
+    // RemoveLinks removes the link with this name instead of the container
+    RemoveLinks bool
 }
 
 func NewDockerClient() (*DockerClient, error) {
@@ -35,13 +139,19 @@ func NewDockerClient() (*DockerClient, error) {
     if err != nil {
         return nil, fmt.Errorf("failed to create Docker client: %w", err)
     }
-    return &DockerClient{cli: cli}, nil
+    return &DockerClient{
+        cli:          cli,
+        statsWorkers: defaultStatsWorkers,
+        statsTimeout: defaultStatsTimeout,
+        prevStats:    map[string]ContainerStats{},
+    }, nil
 }
 
-func (d *DockerClient) ListContainers(all bool) ([]ContainerInfo, error) {
+func (d *DockerClient) ListContainers(opts ListOptions) ([]ContainerInfo, error) {
     ctx := context.Background()
     containers, err := d.cli.ContainerList(ctx, types.ContainerListOptions{
-        All: all,
+        All:     opts.listAll(),
+        Filters: filterArgs(opts.Filters),
     })
     if err != nil {
         return nil, err
@@ -55,35 +165,155 @@ func (d *DockerClient) ListContainers(all bool) ([]ContainerInfo, error) {
             Image:   c.Image,
             Status:  c.Status,
             State:   c.State,
+            Health:  healthFromStatus(c.Status),
             Ports:   formatPorts(c.Ports),
             Created: time.Unix(c.Created, 0),
+            Labels:  c.Labels,
         }
-
-        // Get detailed stats
-        stats, err := d.getContainerStats(c.ID)
-        if err == nil {
-            info.CPU = stats.CPU
-            info.Memory = stats.Memory
-            info.Network = stats.Network
+        if c.NetworkSettings != nil {
+            for name := range c.NetworkSettings.Networks {
+                info.NetworkNames = append(info.NetworkNames, name)
+            }
+            sort.Strings(info.NetworkNames)
         }
-
         result = append(result, info)
     }
+
+    if len(opts.Filters) == 0 {
+        d.forgetStats(result)
+    }
+    if !opts.SkipStats {
+        d.collectStats(result)
+    }
     return result, nil
 }
 
+// forgetStats drops the previous samples of containers that are no longer
+// listed. Stopped containers go too, since their samples are stale by the
+// time they run again.
+func (d *DockerClient) forgetStats(listed []ContainerInfo) {
+    keep := make(map[string]bool, len(listed))
+    for _, c := range listed {
+        if c.State == "running" {
+            keep[c.ID] = true
+        }
+    }
+
+    d.prevMu.Lock()
+    defer d.prevMu.Unlock()
+    for id := range d.prevStats {
+        if !keep[id] {
+            delete(d.prevStats, id)
+        }
+    }
+}
+
+// collectStats fills in stats for the running containers using a bounded
+// pool of workers. Containers whose stats fail or time out are left with
+// HasStats unset so the rest of the list is still usable.
+func (d *DockerClient) collectStats(containers []ContainerInfo) {
+    jobs := make(chan int)
+    var wg sync.WaitGroup
+
+    workers := d.statsWorkers
+    if workers > len(containers) {
+        workers = len(containers)
+    }
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range jobs {
+                ctx, cancel := context.WithTimeout(context.Background(), d.statsTimeout)
+                stats, err := d.getContainerStats(ctx, containers[i].ID)
+                cancel()
+                if err != nil {
+                    continue
+                }
+
+                // Each worker owns index i, so no locking is needed
+                containers[i].applyStats(stats)
+            }
+        }()
+    }
+
+    for i, c := range containers {
+        if c.State == "running" {
+            jobs <- i
+        }
+    }
+    close(jobs)
+    wg.Wait()
+}
+
 func (d *DockerClient) GetContainerStats(containerID string) (*ContainerStats, error) {
-    return d.getContainerStats(containerID)
+    ctx, cancel := context.WithTimeout(context.Background(), d.statsTimeout)
+    defer cancel()
+    return d.getContainerStats(ctx, containerID)
 }
 
 type ContainerStats struct {
-    CPU     float64
-    Memory  float64
+    CPU    float64
+    Memory float64
+
+    // Network is the current receive/transmit rate, or "-" until a
+    // previous sample is available
     Network string
+
+    // Memory in bytes, excluding the page cache like `docker stats`
+    MemUsage uint64
+    MemLimit uint64
+
+    // Cumulative network bytes per interface and summed over all of them
+    Networks map[string]NetworkIO
+    NetRx    uint64
+    NetTx    uint64
+
+    BlockRead  uint64
+    BlockWrite uint64
+
+    PIDs      uint64
+    PIDsLimit uint64
+
+    // Per-second rates since the previous sample, in bytes
+    NetRxRate      float64
+    NetTxRate      float64
+    BlockReadRate  float64
+    BlockWriteRate float64
+    HasRates       bool
+
+    UpdatedAt time.Time
 }
 
-func (d *DockerClient) getContainerStats(containerID string) (*ContainerStats, error) {
-    ctx := context.Background()
+// computeRates derives per-second throughput from the cumulative counters of
+// prev, an earlier sample of the same container.
+func (s *ContainerStats) computeRates(prev *ContainerStats) {
+    secs := s.UpdatedAt.Sub(prev.UpdatedAt).Seconds()
+    if secs <= 0 {
+        return
+    }
+    s.NetRxRate = rate(s.NetRx, prev.NetRx, secs)
+    s.NetTxRate = rate(s.NetTx, prev.NetTx, secs)
+    s.BlockReadRate = rate(s.BlockRead, prev.BlockRead, secs)
+    s.BlockWriteRate = rate(s.BlockWrite, prev.BlockWrite, secs)
+    s.HasRates = true
+    s.Network = fmt.Sprintf("↓%s ↑%s", FormatRate(s.NetRxRate), FormatRate(s.NetTxRate))
+}
+
+func rate(cur, prev uint64, secs float64) float64 {
+    // Counters reset when the container restarts
+    if cur < prev {
+        return 0
+    }
+    return float64(cur-prev) / secs
+}
+
+type NetworkIO struct {
+    RxBytes uint64 `json:"rx_bytes" yaml:"rx_bytes"`
+    TxBytes uint64 `json:"tx_bytes" yaml:"tx_bytes"`
+}
+
+func (d *DockerClient) getContainerStats(ctx context.Context, containerID string) (*ContainerStats, error) {
     stats, err := d.cli.ContainerStats(ctx, containerID, false)
     if err != nil {
         return nil, err
@@ -94,33 +324,54 @@ func (d *DockerClient) getContainerStats(containerID string) (*ContainerStats, e
     if err := json.NewDecoder(stats.Body).Decode(&v); err != nil {
         return nil, err
     }
+    result := newContainerStats(&v, v.PreCPUStats)
 
-    // Calculate CPU percentage
-    cpuDelta := float64(v.CPUStats.CPUUsage.TotalUsage) - float64(v.PreCPUStats.CPUUsage.TotalUsage)
-    systemDelta := float64(v.CPUStats.SystemUsage) - float64(v.PreCPUStats.SystemUsage)
-    cpuPercent := 0.0
-    if systemDelta > 0 {
-        cpuPercent = (cpuDelta / systemDelta) * float64(len(v.CPUStats.CPUUsage.PercpuUsage)) * 100
+    d.prevMu.Lock()
+    if prev, ok := d.prevStats[containerID]; ok {
+        result.computeRates(&prev)
     }
+    d.prevStats[containerID] = *result
+    d.prevMu.Unlock()
+
+    return result, nil
+}
 
+// newContainerStats turns a raw stats sample into ContainerStats, using pre
+// as the previous CPU reading for the CPU delta.
+func newContainerStats(v *types.StatsJSON, pre types.CPUStats) *ContainerStats {
     // Calculate memory usage
-    memUsage := float64(v.MemoryStats.Usage) / 1024 / 1024 // MB
-    memLimit := float64(v.MemoryStats.Limit) / 1024 / 1024 // MB
+    memUsage := calculateMemUsage(v.MemoryStats)
     memPercent := 0.0
-    if memLimit > 0 {
-        memPercent = (memUsage / memLimit) * 100
+    if v.MemoryStats.Limit > 0 {
+        memPercent = float64(memUsage) / float64(v.MemoryStats.Limit) * 100
     }
 
     // Network stats
-    networkRx := float64(v.Networks["eth0"].RxBytes) / 1024 / 1024
-    networkTx := float64(v.Networks["eth0"].TxBytes) / 1024 / 1024
-    network := fmt.Sprintf("↓%.1fM/↑%.1fM", networkRx, networkTx)
+    networks := make(map[string]NetworkIO, len(v.Networks))
+    var netRx, netTx uint64
+    for name, n := range v.Networks {
+        networks[name] = NetworkIO{RxBytes: n.RxBytes, TxBytes: n.TxBytes}
+        netRx += n.RxBytes
+        netTx += n.TxBytes
+    }
+
+    blockRead, blockWrite := calculateBlockIO(v.BlkioStats)
 
     return &ContainerStats{
-        CPU:     cpuPercent,
-        Memory:  memPercent,
-        Network: network,
-    }, nil
+        CPU:        calculateCPUPercent(v.CPUStats, pre),
+        Memory:     memPercent,
+        Network:    "-",
+        MemUsage:   memUsage,
+        MemLimit:   v.MemoryStats.Limit,
+        Networks:   networks,
+        NetRx:      netRx,
+        NetTx:      netTx,
+        BlockRead:  blockRead,
+        BlockWrite: blockWrite,
+        PIDs:       v.PidsStats.Current,
+        PIDsLimit:  v.PidsStats.Limit,
+        UpdatedAt:  v.Read,
+    }
 }
 
 func (d *DockerClient) StartContainer(containerID string) error {
@@ -128,49 +379,44 @@ func (d *DockerClient) StartContainer(containerID string) error {
     return d.cli.ContainerStart(ctx, containerID, types.ContainerStartOptions{})
 }
 
-func (d *DockerClient) StopContainer(containerID string) error {
+func (d *DockerClient) StopContainer(containerID string, opts StopOptions) error {
     ctx := context.Background()
-    return d.cli.ContainerStop(ctx, containerID, container.StopOptions{})
+    return d.cli.ContainerStop(ctx, containerID, opts.dockerOptions())
 }
 
-func (d *DockerClient) RestartContainer(containerID string) error {
+func (d *DockerClient) RestartContainer(containerID string, opts StopOptions) error {
     ctx := context.Background()
-    return d.cli.ContainerRestart(ctx, containerID, container.StopOptions{})
+    return d.cli.ContainerRestart(ctx, containerID, opts.dockerOptions())
 }
 
-func (d *DockerClient) RemoveContainer(containerID string) error {
+func (d *DockerClient) PauseContainer(containerID string) error {
     ctx := context.Background()
-    return d.cli.ContainerRemove(ctx, containerID, types.ContainerRemoveOptions{})
+    return d.cli.ContainerPause(ctx, containerID)
 }
 
-func (d *DockerClient) GetContainerLogs(containerID string) (string, error) {
+func (d *DockerClient) UnpauseContainer(containerID string) error {
     ctx := context.Background()
-    out, err := d.cli.ContainerLogs(ctx, containerID, types.ContainerLogsOptions{
-        ShowStdout: true,
-        ShowStderr: true,
-        Tail:       "100",
-        Follow:     false,
-    })
-    if err != nil {
-        return "", err
-    }
-    defer out.Close()
+    return d.cli.ContainerUnpause(ctx, containerID)
+}
 
-    logBytes, err := io.ReadAll(out)
-    if err != nil {
-        return "", err
-    }
+// KillContainer sends signal (e.g. "SIGTERM" or "9") to the container's
+// main process. An empty signal sends SIGKILL.
+func (d *DockerClient) KillContainer(containerID, signal string) error {
+    ctx := context.Background()
+    return d.cli.ContainerKill(ctx, containerID, signal)
+}
 
-    return string(logBytes), nil
+func (d *DockerClient) RenameContainer(containerID, newName string) error {
+    ctx := context.Background()
+    return d.cli.ContainerRename(ctx, containerID, newName)
 }
 
-func (d *DockerClient) StreamLogs(containerID string) (io.ReadCloser, error) {
+func (d *DockerClient) RemoveContainer(containerID string, opts RemoveOptions) error {
     ctx := context.Background()
-    return d.cli.ContainerLogs(ctx, containerID, types.ContainerLogsOptions{
-        ShowStdout: true,
-        ShowStderr: true,
-        Follow:     true,
-        Since:      time.Now().Format(time.RFC3339),
+    return d.cli.ContainerRemove(ctx, containerID, types.ContainerRemoveOptions{
+        Force:         opts.Force,
+        RemoveVolumes: opts.RemoveVolumes,
+        RemoveLinks:   opts.RemoveLinks,
     })
 }
 
@@ -186,3 +432,15 @@ func formatPorts(ports []types.Port) string {
     }
     return result
 }
+
+// healthFromStatus extracts the health state the daemon appends to the
+// status text, e.g. "Up 5 minutes (healthy)" or "Up 2 seconds (health:
+// starting)".
+func healthFromStatus(status string) string {
+    for _, suffix := range []string{"(healthy)", "(unhealthy)", "(health: starting)"} {
+        if strings.HasSuffix(status, suffix) {
+            return strings.TrimPrefix(strings.Trim(suffix, "()"), "health: ")
+        }
+    }
+    return ""
+}
@@ -0,0 +1,19 @@
+package docker
+
+import "testing"
+
+func TestHealthFromStatus(t *testing.T) {
+    tests := map[string]string{
+        "Up 5 minutes (healthy)":          "healthy",
+        "Up 5 minutes (unhealthy)":        "unhealthy",
+        "Up 2 seconds (health: starting)": "starting",
+        "Up 5 minutes":                    "",
+        "Up 5 minutes (Paused)":           "",
+        "Exited (0) 2 hours ago":          "",
+    }
+    for status, want := range tests {
+        if got := healthFromStatus(status); got != want {
+            t.Errorf("healthFromStatus(%q) = %q, want %q", status, got, want)
+        }
+    }
+}
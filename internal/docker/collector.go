@@ -0,0 +1,176 @@
+package docker
+
+import (
+    "context"
+    "encoding/json"
+    "sync"
+    "time"
+
+    "github.com/docker/docker/api/types"
+    "github.com/docker/docker/client"
+)
+
+const collectorSyncInterval = 5 * time.Second
+
+// StatsSource gives the latest stats sample per container, keyed by the
+// short container ID used in ContainerInfo.
+type StatsSource interface {
+    Snapshot() map[string]ContainerStats
+}
+
+// StatsCollector keeps one streaming stats subscription open per running
+// container instead of polling each of them with a one-shot request.
+type StatsCollector struct {
+    cli *client.Client
+
+    mu     sync.RWMutex
+    latest map[string]ContainerStats
+    subs   map[string]*statsSubscription
+}
+
+type statsSubscription struct {
+    cancel context.CancelFunc
+}
+
+func newStatsCollector(cli *client.Client) *StatsCollector {
+    return &StatsCollector{
+        cli:    cli,
+        latest: map[string]ContainerStats{},
+        subs:   map[string]*statsSubscription{},
+    }
+}
+
+// WatchStats starts a StatsCollector that runs until ctx is cancelled.
+func (d *DockerClient) WatchStats(ctx context.Context) StatsSource {
+    c := newStatsCollector(d.cli)
+    go c.Run(ctx)
+    return c
+}
+
+// Run periodically reconciles subscriptions with the set of running
+// containers until ctx is cancelled.
+func (c *StatsCollector) Run(ctx context.Context) {
+    ticker := time.NewTicker(collectorSyncInterval)
+    defer ticker.Stop()
+
+    for {
+        c.sync(ctx)
+
+        select {
+        case <-ctx.Done():
+            c.mu.Lock()
+            for id, sub := range c.subs {
+                sub.cancel()
+                delete(c.subs, id)
+            }
+            c.mu.Unlock()
+            return
+        case <-ticker.C:
+        }
+    }
+}
+
+func (c *StatsCollector) Snapshot() map[string]ContainerStats {
+    c.mu.RLock()
+    defer c.mu.RUnlock()
+
+    snapshot := make(map[string]ContainerStats, len(c.latest))
+    for id, stats := range c.latest {
+        snapshot[id] = stats
+    }
+    return snapshot
+}
+
+func (c *StatsCollector) sync(ctx context.Context) {
+    containers, err := c.cli.ContainerList(ctx, types.ContainerListOptions{})
+    if err != nil {
+        // Keep the existing subscriptions and try again on the next tick
+        return
+    }
+
+    running := make(map[string]bool, len(containers))
+    for _, ct := range containers {
+        running[ct.ID[:12]] = true
+    }
+
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    for id := range running {
+        if _, ok := c.subs[id]; !ok {
+            c.track(ctx, id)
+        }
+    }
+    for id, sub := range c.subs {
+        if !running[id] {
+            sub.cancel()
+            delete(c.subs, id)
+            delete(c.latest, id)
+        }
+    }
+}
+
+// track must be called with c.mu held.
+func (c *StatsCollector) track(ctx context.Context, id string) {
+    subCtx, cancel := context.WithCancel(ctx)
+    sub := &statsSubscription{cancel: cancel}
+    c.subs[id] = sub
+    go c.subscribe(subCtx, id, sub)
+}
+
+func (c *StatsCollector) subscribe(ctx context.Context, id string, sub *statsSubscription) {
+    defer func() {
+        sub.cancel()
+        c.mu.Lock()
+        // The stream may end on its own when the container stops; forget
+        // it so the next sync can subscribe again if it comes back.
+        if c.subs[id] == sub {
+            delete(c.subs, id)
+            delete(c.latest, id)
+        }
+        c.mu.Unlock()
+    }()
+
+    resp, err := c.cli.ContainerStats(ctx, id, true)
+    if err != nil {
+        return
+    }
+    defer resp.Body.Close()
+
+    dec := json.NewDecoder(resp.Body)
+    var prev *types.StatsJSON
+    var prevStats *ContainerStats
+    for {
+        var v types.StatsJSON
+        if err := dec.Decode(&v); err != nil {
+            return
+        }
+
+        pre := v.PreCPUStats
+        if prev != nil {
+            pre = prev.CPUStats
+        }
+        stats := newContainerStats(&v, pre)
+        if prevStats != nil {
+            stats.computeRates(prevStats)
+        }
+
+        c.mu.Lock()
+        if ctx.Err() == nil {
+            c.latest[id] = *stats
+        }
+        c.mu.Unlock()
+
+        prev = &v
+        prevStats = stats
+    }
+}
+
+// ApplyStats copies the matching samples from snapshot onto containers.
+func ApplyStats(containers []ContainerInfo, snapshot map[string]ContainerStats) {
+    for i := range containers {
+        if stats, ok := snapshot[containers[i].ID]; ok {
+            containers[i].applyStats(&stats)
+        }
+    }
+}
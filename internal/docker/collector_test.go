@@ -0,0 +1,171 @@
+package docker
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/docker/docker/client"
+)
+
+// fakeDaemon answers the container list and stats stream requests the
+// collector makes. Each stats stream sends one sample and then stays open
+// until the client goes away, like a running container's would. Event
+// subscriptions stay open the same way, without any events.
+type fakeDaemon struct {
+    cli *client.Client
+
+    mu      sync.Mutex
+    running []string
+    open    map[string]int
+    // dropEvents is how many event subscriptions end as soon as they're
+    // made
+    dropEvents int
+    subscribed bool
+}
+
+func newFakeDaemon(t *testing.T, running ...string) *fakeDaemon {
+    t.Helper()
+    d := &fakeDaemon{running: running, open: map[string]int{}}
+    srv := httptest.NewServer(http.HandlerFunc(d.serve))
+    t.Cleanup(srv.Close)
+
+    cli, err := client.NewClientWithOpts(
+        client.WithHost("tcp://"+strings.TrimPrefix(srv.URL, "http://")),
+        client.WithHTTPClient(srv.Client()),
+        client.WithVersion("1.43"),
+    )
+    if err != nil {
+        t.Fatal(err)
+    }
+    d.cli = cli
+    return d
+}
+
+func (d *fakeDaemon) setRunning(ids ...string) {
+    d.mu.Lock()
+    defer d.mu.Unlock()
+    d.running = ids
+}
+
+func (d *fakeDaemon) streams(id string) int {
+    d.mu.Lock()
+    defer d.mu.Unlock()
+    return d.open[id]
+}
+
+func (d *fakeDaemon) serve(w http.ResponseWriter, r *http.Request) {
+    switch {
+    case strings.HasSuffix(r.URL.Path, "/containers/json"):
+        d.mu.Lock()
+        var list []map[string]interface{}
+        for _, id := range d.running {
+            list = append(list, map[string]interface{}{"Id": id, "Names": []string{"/" + id[:4]}})
+        }
+        d.mu.Unlock()
+        json.NewEncoder(w).Encode(list)
+
+    case strings.HasSuffix(r.URL.Path, "/stats"):
+        id := strings.TrimSuffix(r.URL.Path[strings.Index(r.URL.Path, "/containers/")+len("/containers/"):], "/stats")
+        d.mu.Lock()
+        d.open[id]++
+        d.mu.Unlock()
+        defer func() {
+            d.mu.Lock()
+            d.open[id]--
+            d.mu.Unlock()
+        }()
+
+        json.NewEncoder(w).Encode(map[string]interface{}{"read": "2024-01-02T13:00:00Z"})
+        w.(http.Flusher).Flush()
+        <-r.Context().Done()
+
+    case strings.HasSuffix(r.URL.Path, "/events"):
+        d.mu.Lock()
+        drop := d.dropEvents > 0
+        if drop {
+            d.dropEvents--
+        }
+        d.mu.Unlock()
+        if drop {
+            return
+        }
+
+        // Answer slowly, so a client that doesn't wait for the answer
+        // would get ahead of the subscription
+        time.Sleep(50 * time.Millisecond)
+        d.mu.Lock()
+        d.subscribed = true
+        d.mu.Unlock()
+        w.(http.Flusher).Flush()
+        <-r.Context().Done()
+
+    default:
+        http.NotFound(w, r)
+    }
+}
+
+func (d *fakeDaemon) isSubscribed() bool {
+    d.mu.Lock()
+    defer d.mu.Unlock()
+    return d.subscribed
+}
+
+// waitFor polls cond until it holds or a second has passed.
+func waitFor(t *testing.T, what string, cond func() bool) {
+    t.Helper()
+    deadline := time.Now().Add(time.Second)
+    for !cond() {
+        if time.Now().After(deadline) {
+            t.Fatalf("timed out waiting for %s", what)
+        }
+        time.Sleep(5 * time.Millisecond)
+    }
+}
+
+func TestStatsCollectorFollowsRunningContainers(t *testing.T) {
+    web := strings.Repeat("a", 64)
+    db := strings.Repeat("b", 64)
+    daemon := newFakeDaemon(t, web, db)
+
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    c := newStatsCollector(daemon.cli)
+
+    // Samples are keyed by the short ID, like ContainerInfo
+    c.sync(ctx)
+    waitFor(t, "both samples", func() bool { return len(c.Snapshot()) == 2 })
+    snapshot := c.Snapshot()
+    for _, id := range []string{web[:12], db[:12]} {
+        if stats, ok := snapshot[id]; !ok || stats.UpdatedAt.IsZero() {
+            t.Errorf("snapshot[%s] = %+v, %v; want a sample", id, stats, ok)
+        }
+    }
+
+    // A stopped container's subscription is dropped along with its sample
+    daemon.setRunning(db)
+    c.sync(ctx)
+    if _, ok := c.Snapshot()[web[:12]]; ok {
+        t.Errorf("web still in the snapshot after it stopped")
+    }
+    waitFor(t, "web's stream to close", func() bool { return daemon.streams(web[:12]) == 0 })
+
+    // A sync doesn't open a second stream for a container already followed,
+    // and picks up one that started since
+    daemon.setRunning(db, web)
+    c.sync(ctx)
+    waitFor(t, "web's sample again", func() bool { return len(c.Snapshot()) == 2 })
+    if n := daemon.streams(db[:12]); n != 1 {
+        t.Errorf("db has %d open streams, want 1", n)
+    }
+
+    cancel()
+    waitFor(t, "all streams to close", func() bool {
+        return daemon.streams(web[:12]) == 0 && daemon.streams(db[:12]) == 0
+    })
+}
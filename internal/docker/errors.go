@@ -0,0 +1,17 @@
+package docker
+
+import (
+    "errors"
+
+    "github.com/docker/docker/client"
+)
+
+// ErrDaemonUnreachable can be returned by a ContainerRuntime, such as a
+// scripted FakeRuntime, to report that the daemon can't be reached.
+var ErrDaemonUnreachable = errors.New("cannot connect to the Docker daemon")
+
+// IsConnectionError reports whether err means the daemon is unreachable,
+// as opposed to an operation failing.
+func IsConnectionError(err error) bool {
+    return errors.Is(err, ErrDaemonUnreachable) || client.IsErrConnectionFailed(err)
+}
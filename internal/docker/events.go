@@ -0,0 +1,167 @@
+package docker
+
+import (
+    "context"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/docker/docker/api/types"
+    "github.com/docker/docker/api/types/events"
+    "github.com/docker/docker/api/types/filters"
+)
+
+const (
+    eventsMinBackoff = time.Second
+    eventsMaxBackoff = 30 * time.Second
+)
+
+type ContainerEventAction string
+
+const (
+    EventCreate  ContainerEventAction = "create"
+    EventStart   ContainerEventAction = "start"
+    EventDie     ContainerEventAction = "die"
+    EventDestroy ContainerEventAction = "destroy"
+    EventHealth  ContainerEventAction = "health_status"
+    EventRename  ContainerEventAction = "rename"
+    EventPause   ContainerEventAction = "pause"
+    EventUnpause ContainerEventAction = "unpause"
+
+    // EventResync is sent after the event stream had to reconnect. Events
+    // may have been missed, so the container list should be reloaded.
+    EventResync ContainerEventAction = "resync"
+)
+
+type ContainerEvent struct {
+    Action   ContainerEventAction
+    ID       string
+    Name     string
+    Image    string
+    ExitCode int
+    Health   string
+    Time     time.Time
+}
+
+// WatchEvents subscribes to container events from the daemon. The stream is
+// reopened with exponential backoff whenever it drops, and an EventResync is
+// sent once the new subscription is in place, so nothing that happens after
+// the reload it triggers can be missed. The channel is closed once ctx is
+// cancelled.
+func (d *DockerClient) WatchEvents(ctx context.Context) <-chan ContainerEvent {
+    out := make(chan ContainerEvent)
+
+    go func() {
+        defer close(out)
+
+        backoff := eventsMinBackoff
+        for attempt := 0; ; attempt++ {
+            var resync func()
+            if attempt > 0 {
+                resync = func() {
+                    select {
+                    case out <- ContainerEvent{Action: EventResync, Time: time.Now()}:
+                    case <-ctx.Done():
+                    }
+                }
+            }
+
+            received := d.consumeEvents(ctx, out, resync)
+            if ctx.Err() != nil {
+                return
+            }
+            if received {
+                backoff = eventsMinBackoff
+            }
+
+            select {
+            case <-time.After(backoff):
+            case <-ctx.Done():
+                return
+            }
+            backoff *= 2
+            if backoff > eventsMaxBackoff {
+                backoff = eventsMaxBackoff
+            }
+        }
+    }()
+
+    return out
+}
+
+// consumeEvents forwards events until the stream fails and reports whether
+// anything was received, so a healthy connection resets the backoff.
+// subscribed, if set, is called once the daemon has accepted the
+// subscription and before any event is forwarded.
+func (d *DockerClient) consumeEvents(ctx context.Context, out chan<- ContainerEvent, subscribed func()) bool {
+    msgs, errs := d.cli.Events(ctx, types.EventsOptions{
+        Filters: filters.NewArgs(filters.Arg("type", events.ContainerEventType)),
+    })
+
+    // Events returns once the daemon has answered, so unless the request
+    // failed the subscription is in place
+    select {
+    case <-errs:
+        return false
+    default:
+    }
+    if subscribed != nil {
+        subscribed()
+    }
+
+    received := false
+    for {
+        select {
+        case msg := <-msgs:
+            received = true
+            ev, ok := translateEvent(msg)
+            if !ok {
+                continue
+            }
+            select {
+            case out <- ev:
+            case <-ctx.Done():
+                return received
+            }
+        case <-errs:
+            return received
+        case <-ctx.Done():
+            return received
+        }
+    }
+}
+
+func translateEvent(msg events.Message) (ContainerEvent, bool) {
+    action := msg.Action
+    health := ""
+    // Health events carry the new status in the action, e.g.
+    // "health_status: healthy"
+    if strings.HasPrefix(action, string(EventHealth)) {
+        health = strings.TrimSpace(strings.TrimPrefix(action, string(EventHealth)+":"))
+        action = string(EventHealth)
+    }
+
+    switch ContainerEventAction(action) {
+    case EventCreate, EventStart, EventDie, EventDestroy, EventHealth, EventRename, EventPause, EventUnpause:
+    default:
+        return ContainerEvent{}, false
+    }
+
+    id := msg.Actor.ID
+    if len(id) > 12 {
+        id = id[:12]
+    }
+
+    ev := ContainerEvent{
+        Action: ContainerEventAction(action),
+        ID:     id,
+        Name:   msg.Actor.Attributes["name"],
+        Image:  msg.Actor.Attributes["image"],
+        Health: health,
+        Time:   time.Unix(0, msg.TimeNano),
+    }
+    if code, err := strconv.Atoi(msg.Actor.Attributes["exitCode"]); err == nil {
+        ev.ExitCode = code
+    }
+    return ev, true
+}
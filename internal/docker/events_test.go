@@ -0,0 +1,89 @@
+package docker
+
+import (
+    "context"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/docker/docker/api/types/events"
+)
+
+func TestTranslateEvent(t *testing.T) {
+    id := strings.Repeat("a", 64)
+    at := time.Date(2024, 1, 2, 13, 0, 0, 0, time.UTC)
+    msg := func(action string, attrs map[string]string) events.Message {
+        return events.Message{
+            Type:     events.ContainerEventType,
+            Action:   action,
+            Actor:    events.Actor{ID: id, Attributes: attrs},
+            TimeNano: at.UnixNano(),
+        }
+    }
+
+    tests := []struct {
+        name string
+        msg  events.Message
+        want ContainerEvent
+        ok   bool
+    }{
+        {
+            "start",
+            msg("start", map[string]string{"name": "web", "image": "nginx"}),
+            ContainerEvent{Action: EventStart, ID: id[:12], Name: "web", Image: "nginx", Time: at},
+            true,
+        },
+        {
+            "die carries the exit code",
+            msg("die", map[string]string{"name": "web", "exitCode": "137"}),
+            ContainerEvent{Action: EventDie, ID: id[:12], Name: "web", ExitCode: 137, Time: at},
+            true,
+        },
+        {
+            "health status is split off the action",
+            msg("health_status: unhealthy", map[string]string{"name": "web"}),
+            ContainerEvent{Action: EventHealth, ID: id[:12], Name: "web", Health: "unhealthy", Time: at},
+            true,
+        },
+        {
+            "rename has the new name",
+            msg("rename", map[string]string{"name": "api", "oldName": "/web"}),
+            ContainerEvent{Action: EventRename, ID: id[:12], Name: "api", Time: at},
+            true,
+        },
+        {"destroy", msg("destroy", nil), ContainerEvent{Action: EventDestroy, ID: id[:12], Time: at}, true},
+        {"exec events are ignored", msg("exec_start: sh", nil), ContainerEvent{}, false},
+        {"attach events are ignored", msg("attach", nil), ContainerEvent{}, false},
+    }
+    for _, tt := range tests {
+        got, ok := translateEvent(tt.msg)
+        // Time is in the local zone
+        if got.Time.Equal(tt.want.Time) {
+            got.Time = tt.want.Time
+        }
+        if ok != tt.ok || got != tt.want {
+            t.Errorf("%s: got %+v, %v; want %+v, %v", tt.name, got, ok, tt.want, tt.ok)
+        }
+    }
+}
+
+func TestWatchEventsResyncsOnceResubscribed(t *testing.T) {
+    daemon := newFakeDaemon(t)
+    daemon.dropEvents = 1
+
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    events := (&DockerClient{cli: daemon.cli}).WatchEvents(ctx)
+
+    select {
+    case ev := <-events:
+        if ev.Action != EventResync {
+            t.Fatalf("got %+v, want a resync", ev)
+        }
+        if !daemon.isSubscribed() {
+            t.Error("resync sent before the new subscription was in place")
+        }
+    case <-time.After(5 * time.Second):
+        t.Fatal("no resync after the stream dropped")
+    }
+}
@@ -0,0 +1,192 @@
+package docker
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "os"
+    "time"
+
+    "github.com/docker/docker/api/types"
+    "github.com/docker/docker/pkg/stdcopy"
+    "github.com/muesli/cancelreader"
+    "golang.org/x/term"
+)
+
+// ShellCommand starts bash if the container has it and sh otherwise.
+var ShellCommand = []string{"/bin/sh", "-c", "if command -v bash >/dev/null 2>&1; then exec bash; else exec sh; fi"}
+
+// ExecOptions describes a command to run inside a running container.
+type ExecOptions struct {
+    Cmd        []string
+    User       string
+    WorkingDir string
+    Env        []string
+    // Tty allocates a terminal. Its output is then a single raw stream
+    // instead of separate stdout and stderr.
+    Tty bool
+    // Stdin attaches the caller's input to the command
+    Stdin bool
+    // Width and Height are the initial terminal size, if known
+    Width  uint
+    Height uint
+}
+
+// ExecSession is a command started by ExecContainer. Stream or Attach runs
+// it to completion, after which ExitCode is available.
+type ExecSession struct {
+    ID  string
+    Tty bool
+
+    stream   func(stdin io.Reader, stdout, stderr io.Writer) error
+    resize   func(width, height uint) error
+    exitCode func() (int, error)
+    close    func()
+}
+
+// Stream copies stdin to the command and its output to stdout and stderr
+// until the command exits. stdin may be nil.
+func (s *ExecSession) Stream(stdin io.Reader, stdout, stderr io.Writer) error {
+    return s.stream(stdin, stdout, stderr)
+}
+
+// Resize changes the size of the command's terminal.
+func (s *ExecSession) Resize(width, height uint) error {
+    return s.resize(width, height)
+}
+
+// ExitCode returns the command's exit code once it has finished.
+func (s *ExecSession) ExitCode() (int, error) {
+    return s.exitCode()
+}
+
+// Close releases the connection to the command.
+func (s *ExecSession) Close() {
+    s.close()
+}
+
+// Attach connects a terminal to a session started with Tty: the terminal is
+// put in raw mode and its size follows the window until the command exits.
+// Without a terminal on stdin it behaves like Stream.
+func (s *ExecSession) Attach(stdin io.Reader, stdout, stderr io.Writer) error {
+    f, ok := stdin.(*os.File)
+    if !ok || !s.Tty || !term.IsTerminal(int(f.Fd())) {
+        return s.Stream(stdin, stdout, stderr)
+    }
+
+    fd := int(f.Fd())
+    state, err := term.MakeRaw(fd)
+    if err != nil {
+        return fmt.Errorf("setting terminal to raw mode: %w", err)
+    }
+    defer term.Restore(fd, state)
+
+    resize := func() {
+        if width, height := TerminalSize(f); width > 0 && height > 0 {
+            // The command may already have exited; nothing to resize then
+            s.Resize(width, height)
+        }
+    }
+    resize()
+    stop := watchResize(fd, resize)
+    defer stop()
+
+    return s.Stream(stdin, stdout, stderr)
+}
+
+// TerminalSize returns the size of the terminal r reads from, or zero if it
+// isn't one.
+func TerminalSize(r io.Reader) (width, height uint) {
+    f, ok := r.(*os.File)
+    if !ok {
+        return 0, 0
+    }
+    w, h, err := term.GetSize(int(f.Fd()))
+    if err != nil || w <= 0 || h <= 0 {
+        return 0, 0
+    }
+    return uint(w), uint(h)
+}
+
+func (d *DockerClient) ExecContainer(ctx context.Context, containerID string, opts ExecOptions) (*ExecSession, error) {
+    cfg := types.ExecConfig{
+        User:         opts.User,
+        WorkingDir:   opts.WorkingDir,
+        Env:          opts.Env,
+        Cmd:          opts.Cmd,
+        Tty:          opts.Tty,
+        AttachStdin:  opts.Stdin,
+        AttachStdout: true,
+        AttachStderr: true,
+    }
+    if opts.Tty && opts.Width > 0 && opts.Height > 0 {
+        cfg.ConsoleSize = &[2]uint{opts.Height, opts.Width}
+    }
+
+    created, err := d.cli.ContainerExecCreate(ctx, containerID, cfg)
+    if err != nil {
+        return nil, err
+    }
+    resp, err := d.cli.ContainerExecAttach(ctx, created.ID, types.ExecStartCheck{Tty: opts.Tty, ConsoleSize: cfg.ConsoleSize})
+    if err != nil {
+        return nil, err
+    }
+
+    execID := created.ID
+    return &ExecSession{
+        ID:  execID,
+        Tty: opts.Tty,
+        stream: func(stdin io.Reader, stdout, stderr io.Writer) error {
+            if stdin != nil && opts.Stdin {
+                // A terminal read would otherwise still be blocked when the
+                // command exits and swallow the next key press
+                in, err := cancelreader.NewReader(stdin)
+                if err != nil {
+                    return err
+                }
+                copied := make(chan struct{})
+                go func() {
+                    defer close(copied)
+                    io.Copy(resp.Conn, in)
+                    resp.CloseWrite()
+                }()
+                defer func() {
+                    if in.Cancel() {
+                        <-copied
+                    }
+                    in.Close()
+                }()
+            }
+            if opts.Tty {
+                _, err := io.Copy(stdout, resp.Reader)
+                return err
+            }
+            _, err := stdcopy.StdCopy(stdout, stderr, resp.Reader)
+            return err
+        },
+        resize: func(width, height uint) error {
+            return d.cli.ContainerExecResize(ctx, execID, types.ResizeOptions{Width: width, Height: height})
+        },
+        exitCode: func() (int, error) {
+            // The daemon can take a moment to record the exit after the
+            // output has ended
+            ticker := time.NewTicker(50 * time.Millisecond)
+            defer ticker.Stop()
+            for {
+                info, err := d.cli.ContainerExecInspect(ctx, execID)
+                if err != nil {
+                    return 0, err
+                }
+                if !info.Running {
+                    return info.ExitCode, nil
+                }
+                select {
+                case <-ticker.C:
+                case <-ctx.Done():
+                    return 0, fmt.Errorf("waiting for exec %s to exit: %w", execID, ctx.Err())
+                }
+            }
+        },
+        close: resp.Close,
+    }, nil
+}
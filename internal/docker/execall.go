@@ -0,0 +1,75 @@
+package docker
+
+import (
+    "bytes"
+    "context"
+    "sync"
+    "time"
+)
+
+// ExecResult is the outcome of a non-interactive command in one container.
+// Err is set when the command couldn't be run at all.
+type ExecResult struct {
+    Container ContainerInfo
+    Stdout    string
+    Stderr    string
+    ExitCode  int
+    Duration  time.Duration
+    Err       error
+}
+
+// Failed reports whether the command couldn't run or exited non-zero.
+func (r ExecResult) Failed() bool {
+    return r.Err != nil || r.ExitCode != 0
+}
+
+// RunExec runs a command in c to completion and collects its output. The
+// command gets no terminal and no input.
+func RunExec(ctx context.Context, runtime ContainerRuntime, c ContainerInfo, opts ExecOptions) ExecResult {
+    opts.Tty, opts.Stdin = false, false
+    result := ExecResult{Container: c}
+    start := time.Now()
+
+    session, err := runtime.ExecContainer(ctx, c.ID, opts)
+    if err != nil {
+        result.Err = err
+        result.Duration = time.Since(start)
+        return result
+    }
+    defer session.Close()
+
+    var stdout, stderr bytes.Buffer
+    if err := session.Stream(nil, &stdout, &stderr); err != nil {
+        result.Err = err
+    } else {
+        result.ExitCode, result.Err = session.ExitCode()
+    }
+    result.Stdout, result.Stderr = stdout.String(), stderr.String()
+    result.Duration = time.Since(start)
+    return result
+}
+
+// ExecAll runs a command in every container, at most parallel at once, and
+// returns the results in the order of containers.
+func ExecAll(ctx context.Context, runtime ContainerRuntime, containers []ContainerInfo, opts ExecOptions, parallel int) []ExecResult {
+    results := make([]ExecResult, len(containers))
+    jobs := make(chan int)
+    var wg sync.WaitGroup
+
+    workers := min(max(parallel, 1), len(containers))
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range jobs {
+                results[i] = RunExec(ctx, runtime, containers[i], opts)
+            }
+        }()
+    }
+    for i := range containers {
+        jobs <- i
+    }
+    close(jobs)
+    wg.Wait()
+    return results
+}
@@ -0,0 +1,90 @@
+package docker
+
+import (
+    "context"
+    "sync"
+    "testing"
+    "time"
+)
+
+// slowExecRuntime delays every exec so that concurrent ones overlap, and
+// records how many ran at once.
+type slowExecRuntime struct {
+    *FakeRuntime
+
+    mu      sync.Mutex
+    running int
+    peak    int
+}
+
+func (r *slowExecRuntime) ExecContainer(ctx context.Context, containerID string, opts ExecOptions) (*ExecSession, error) {
+    r.mu.Lock()
+    r.running++
+    r.peak = max(r.peak, r.running)
+    r.mu.Unlock()
+
+    time.Sleep(20 * time.Millisecond)
+
+    r.mu.Lock()
+    r.running--
+    r.mu.Unlock()
+    return r.FakeRuntime.ExecContainer(ctx, containerID, opts)
+}
+
+func execTestContainers() []ContainerInfo {
+    return []ContainerInfo{
+        {ID: "aaaaaaaaaaaa", Name: "web", State: "running"},
+        {ID: "bbbbbbbbbbbb", Name: "db", State: "running"},
+        {ID: "cccccccccccc", Name: "job", State: "exited"},
+        {ID: "dddddddddddd", Name: "cache", State: "running"},
+        {ID: "eeeeeeeeeeee", Name: "worker", State: "running"},
+    }
+}
+
+func TestExecAllKeepsContainerOrder(t *testing.T) {
+    containers := execTestContainers()
+    fake := NewFakeRuntime(containers...)
+    fake.Execs["aaaaaaaaaaaa"] = FakeExec{Stdout: "web\n"}
+    fake.Execs["bbbbbbbbbbbb"] = FakeExec{Stdout: "db\n", Stderr: "warning\n", ExitCode: 3}
+    fake.Execs["dddddddddddd"] = FakeExec{Stdout: "cache\n"}
+    fake.Execs["eeeeeeeeeeee"] = FakeExec{Stdout: "worker\n"}
+
+    results := ExecAll(context.Background(), fake, containers, ExecOptions{Cmd: []string{"hostname"}}, 2)
+
+    if len(results) != len(containers) {
+        t.Fatalf("got %d results, want %d", len(results), len(containers))
+    }
+    for i, r := range results {
+        if r.Container.ID != containers[i].ID {
+            t.Errorf("result %d is for %s, want %s", i, r.Container.Name, containers[i].Name)
+        }
+    }
+    if r := results[0]; r.Stdout != "web\n" || r.Failed() {
+        t.Errorf("web: %+v", r)
+    }
+    if r := results[1]; r.Stdout != "db\n" || r.Stderr != "warning\n" || r.ExitCode != 3 || r.Err != nil || !r.Failed() {
+        t.Errorf("db: %+v, want exit code 3", r)
+    }
+    if r := results[2]; r.Err == nil || !r.Failed() {
+        t.Errorf("job isn't running but no error: %+v", r)
+    }
+}
+
+func TestExecAllBoundsParallelism(t *testing.T) {
+    tests := []struct {
+        parallel int
+        want     int
+    }{
+        {1, 1},
+        {2, 2},
+        {0, 1},
+        {10, 5},
+    }
+    for _, tt := range tests {
+        runtime := &slowExecRuntime{FakeRuntime: NewFakeRuntime(execTestContainers()...)}
+        ExecAll(context.Background(), runtime, execTestContainers(), ExecOptions{Cmd: []string{"true"}}, tt.parallel)
+        if runtime.peak != tt.want {
+            t.Errorf("parallel %d: %d ran at once, want %d", tt.parallel, runtime.peak, tt.want)
+        }
+    }
+}
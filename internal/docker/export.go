@@ -0,0 +1,133 @@
+package docker
+
+import (
+    "bufio"
+    "compress/gzip"
+    "encoding/json"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+)
+
+const (
+    ExportText  = "text"
+    ExportJSONL = "jsonl"
+)
+
+type ExportOptions struct {
+    // Format is ExportText or ExportJSONL
+    Format string
+    // Gzip compresses the file. Paths ending in .gz are always compressed.
+    Gzip bool
+    // Timestamps prefixes text lines with their timestamp; JSON lines
+    // always carry it
+    Timestamps bool
+}
+
+// ParseExportFormat accepts the export format names, defaulting to text.
+func ParseExportFormat(s string) (string, error) {
+    switch strings.ToLower(s) {
+    case "", "text", "txt", "raw":
+        return ExportText, nil
+    case "jsonl", "json", "ndjson":
+        return ExportJSONL, nil
+    }
+    return "", fmt.Errorf("unknown export format %q (want text or jsonl)", s)
+}
+
+// ExportFormatFor guesses the format from a file name such as app.jsonl.gz.
+func ExportFormatFor(path string) string {
+    name := strings.TrimSuffix(path, ".gz")
+    if strings.HasSuffix(name, ".jsonl") || strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".ndjson") {
+        return ExportJSONL
+    }
+    return ExportText
+}
+
+// LogExporter writes log lines to a file as they arrive.
+type LogExporter struct {
+    file  *os.File
+    gz    *gzip.Writer
+    w     *bufio.Writer
+    opts  ExportOptions
+    count int
+}
+
+// CreateLogExport creates or truncates path for writing lines.
+func CreateLogExport(path string, opts ExportOptions) (*LogExporter, error) {
+    format, err := ParseExportFormat(opts.Format)
+    if err != nil {
+        return nil, err
+    }
+    opts.Format = format
+
+    f, err := os.Create(path)
+    if err != nil {
+        return nil, err
+    }
+
+    e := &LogExporter{file: f, opts: opts}
+    var w io.Writer = f
+    if opts.Gzip || strings.HasSuffix(path, ".gz") {
+        e.gz = gzip.NewWriter(f)
+        w = e.gz
+    }
+    e.w = bufio.NewWriter(w)
+    return e, nil
+}
+
+func (e *LogExporter) Write(line LogLine) error {
+    e.count++
+    if e.opts.Format == ExportJSONL {
+        data, err := json.Marshal(line)
+        if err != nil {
+            return err
+        }
+        data = append(data, '\n')
+        _, err = e.w.Write(data)
+        return err
+    }
+
+    text := line.Format(e.opts.Timestamps)
+    if line.Container != "" {
+        text = line.Container + " | " + text
+    }
+    _, err := e.w.WriteString(text + "\n")
+    return err
+}
+
+// Count returns the number of lines written so far.
+func (e *LogExporter) Count() int {
+    return e.count
+}
+
+// Close flushes everything to disk. The file is only complete once Close
+// has returned without error.
+func (e *LogExporter) Close() error {
+    err := e.w.Flush()
+    if e.gz != nil {
+        if gzErr := e.gz.Close(); err == nil {
+            err = gzErr
+        }
+    }
+    if closeErr := e.file.Close(); err == nil {
+        err = closeErr
+    }
+    return err
+}
+
+// ExportLogs writes lines to path in one go.
+func ExportLogs(path string, lines []LogLine, opts ExportOptions) error {
+    e, err := CreateLogExport(path, opts)
+    if err != nil {
+        return err
+    }
+    for _, line := range lines {
+        if err := e.Write(line); err != nil {
+            e.Close()
+            return err
+        }
+    }
+    return e.Close()
+}
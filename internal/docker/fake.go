@@ -0,0 +1,398 @@
+package docker
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "io"
+    "strconv"
+    "strings"
+    "sync"
+)
+
+// FakeRuntime is a scriptable in-memory ContainerRuntime. Containers, Stats
+// and Logs can be populated directly; Fail makes a method return an error.
+type FakeRuntime struct {
+    mu sync.Mutex
+
+    Containers []ContainerInfo
+    Stats      map[string]*ContainerStats
+    Logs       map[string][]LogLine
+    Execs      map[string]FakeExec
+
+    errs     map[string]error
+    calls    []string
+    watchers []chan ContainerEvent
+}
+
+// FakeExec is the scripted result of any command run in a container by
+// ExecContainer, keyed by container ID in FakeRuntime.Execs.
+type FakeExec struct {
+    Stdout   string
+    Stderr   string
+    ExitCode int
+}
+
+func NewFakeRuntime(containers ...ContainerInfo) *FakeRuntime {
+    return &FakeRuntime{
+        Containers: containers,
+        Stats:      map[string]*ContainerStats{},
+        Logs:       map[string][]LogLine{},
+        Execs:      map[string]FakeExec{},
+        errs:       map[string]error{},
+    }
+}
+
+// Fail makes every following call to method (e.g. "StopContainer") return
+// err. A nil err clears the failure.
+func (f *FakeRuntime) Fail(method string, err error) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if err == nil {
+        delete(f.errs, method)
+        return
+    }
+    f.errs[method] = err
+}
+
+// Calls returns the methods invoked so far as "Method(arg)".
+func (f *FakeRuntime) Calls() []string {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    return append([]string(nil), f.calls...)
+}
+
+// Ping reports a fake daemon. Fail("Ping", ErrDaemonUnreachable) simulates
+// one that is down.
+func (f *FakeRuntime) Ping(ctx context.Context) (ServerInfo, error) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if err := f.record("Ping", ""); err != nil {
+        return ServerInfo{}, err
+    }
+    return ServerInfo{Version: "fake", APIVersion: "1.43", OS: "linux", Arch: "amd64"}, nil
+}
+
+func (f *FakeRuntime) ListContainers(opts ListOptions) ([]ContainerInfo, error) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if err := f.record("ListContainers", fmt.Sprintf("%+v", opts)); err != nil {
+        return nil, err
+    }
+
+    var result []ContainerInfo
+    for _, c := range f.Containers {
+        if !opts.listAll() && c.State != "running" {
+            continue
+        }
+        if !MatchFilters(c, opts.Filters) {
+            continue
+        }
+        if stats, ok := f.Stats[c.ID]; ok && !opts.SkipStats {
+            c.applyStats(stats)
+        }
+        result = append(result, c)
+    }
+    return result, nil
+}
+
+func (f *FakeRuntime) GetContainerStats(containerID string) (*ContainerStats, error) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if err := f.record("GetContainerStats", containerID); err != nil {
+        return nil, err
+    }
+
+    i, err := f.find(containerID)
+    if err != nil {
+        return nil, err
+    }
+    stats, ok := f.Stats[f.Containers[i].ID]
+    if !ok {
+        return &ContainerStats{}, nil
+    }
+    copied := *stats
+    return &copied, nil
+}
+
+// WatchStats returns the fake itself; its snapshot is whatever Stats holds
+// at the time it is read.
+func (f *FakeRuntime) WatchStats(ctx context.Context) StatsSource {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    f.record("WatchStats", "")
+    return f
+}
+
+func (f *FakeRuntime) Snapshot() map[string]ContainerStats {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+
+    snapshot := make(map[string]ContainerStats, len(f.Stats))
+    for id, stats := range f.Stats {
+        snapshot[id] = *stats
+    }
+    return snapshot
+}
+
+func (f *FakeRuntime) WatchEvents(ctx context.Context) <-chan ContainerEvent {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    f.record("WatchEvents", "")
+
+    ch := make(chan ContainerEvent, 64)
+    f.watchers = append(f.watchers, ch)
+    go func() {
+        <-ctx.Done()
+        f.mu.Lock()
+        defer f.mu.Unlock()
+        for i, w := range f.watchers {
+            if w == ch {
+                f.watchers = append(f.watchers[:i], f.watchers[i+1:]...)
+                break
+            }
+        }
+        close(ch)
+    }()
+    return ch
+}
+
+// Emit delivers ev to every active WatchEvents subscriber. Events are
+// dropped for subscribers that have fallen 64 events behind.
+func (f *FakeRuntime) Emit(ev ContainerEvent) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    for _, ch := range f.watchers {
+        select {
+        case ch <- ev:
+        default:
+        }
+    }
+}
+
+func (f *FakeRuntime) StartContainer(containerID string) error {
+    return f.setState("StartContainer", containerID, "running", "Up Less than a second")
+}
+
+// StopContainer and RestartContainer finish at once whatever the options.
+func (f *FakeRuntime) StopContainer(containerID string, opts StopOptions) error {
+    return f.setState("StopContainer", containerID, "exited", "Exited (0) Less than a second ago")
+}
+
+func (f *FakeRuntime) RestartContainer(containerID string, opts StopOptions) error {
+    return f.setState("RestartContainer", containerID, "running", "Up Less than a second")
+}
+
+func (f *FakeRuntime) PauseContainer(containerID string) error {
+    return f.setState("PauseContainer", containerID, "paused", "Up Less than a second (Paused)")
+}
+
+func (f *FakeRuntime) UnpauseContainer(containerID string) error {
+    return f.setState("UnpauseContainer", containerID, "running", "Up Less than a second")
+}
+
+// KillContainer stops the container as if its process died from signal;
+// the exit code is always 137.
+func (f *FakeRuntime) KillContainer(containerID, signal string) error {
+    return f.setState("KillContainer", containerID, "exited", "Exited (137) Less than a second ago")
+}
+
+// RenameContainer rejects names already in use, like the daemon.
+func (f *FakeRuntime) RenameContainer(containerID, newName string) error {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if err := f.record("RenameContainer", containerID+" "+newName); err != nil {
+        return err
+    }
+
+    i, err := f.find(containerID)
+    if err != nil {
+        return err
+    }
+    for _, c := range f.Containers {
+        if c.Name == newName {
+            return fmt.Errorf("Conflict. The container name %q is already in use by container %q", "/"+newName, c.ID)
+        }
+    }
+    f.Containers[i].Name = newName
+    return nil
+}
+
+// RemoveContainer refuses running containers unless forced, like the
+// daemon. Volumes and links are not modelled.
+func (f *FakeRuntime) RemoveContainer(containerID string, opts RemoveOptions) error {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if err := f.record("RemoveContainer", containerID); err != nil {
+        return err
+    }
+
+    i, err := f.find(containerID)
+    if err != nil {
+        return err
+    }
+    if f.Containers[i].State == "running" && !opts.Force {
+        return fmt.Errorf("cannot remove running container %s: stop the container before attempting removal or force remove", f.Containers[i].Name)
+    }
+    delete(f.Stats, f.Containers[i].ID)
+    delete(f.Logs, f.Containers[i].ID)
+    f.Containers = append(f.Containers[:i], f.Containers[i+1:]...)
+    return nil
+}
+
+// InspectContainer derives the details from the listed container; Raw is
+// the details marshalled back to JSON.
+func (f *FakeRuntime) InspectContainer(containerID string) (*ContainerDetails, error) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if err := f.record("InspectContainer", containerID); err != nil {
+        return nil, err
+    }
+
+    i, err := f.find(containerID)
+    if err != nil {
+        return nil, err
+    }
+    c := f.Containers[i]
+    d := &ContainerDetails{
+        ID:      c.ID,
+        Name:    c.Name,
+        Image:   c.Image,
+        Created: c.Created,
+        Config:  ContainerConfig{Labels: c.Labels},
+        State: ContainerState{
+            Status:  c.State,
+            Running: c.State == "running",
+            Paused:  c.State == "paused",
+        },
+    }
+    if c.Health != "" {
+        d.State.Health = &HealthState{Status: c.Health}
+    }
+    for _, n := range c.NetworkNames {
+        d.Networks = append(d.Networks, NetworkEndpoint{Name: n})
+    }
+    d.Raw, err = json.MarshalIndent(d, "", "  ")
+    return d, err
+}
+
+// GetContainerLogs honours Tail and the stream selection; the other
+// options are ignored.
+func (f *FakeRuntime) GetContainerLogs(containerID string, opts LogOptions) ([]LogLine, error) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if err := f.record("GetContainerLogs", containerID); err != nil {
+        return nil, err
+    }
+
+    i, err := f.find(containerID)
+    if err != nil {
+        return nil, err
+    }
+
+    selected := opts.dockerOptions()
+    var lines []LogLine
+    for _, line := range f.Logs[f.Containers[i].ID] {
+        if (line.Stream == StreamStderr && selected.ShowStderr) || (line.Stream != StreamStderr && selected.ShowStdout) {
+            lines = append(lines, line)
+        }
+    }
+    if n, err := strconv.Atoi(opts.Tail); err == nil && n < len(lines) {
+        lines = lines[len(lines)-n:]
+    }
+    return lines, nil
+}
+
+// StreamLogs replays the scripted lines and then ends, as if the container
+// had stopped.
+func (f *FakeRuntime) StreamLogs(ctx context.Context, containerID string, opts LogOptions) (*LogStream, error) {
+    lines, err := f.GetContainerLogs(containerID, opts)
+    if err != nil {
+        return nil, err
+    }
+
+    ctx, cancel := context.WithCancel(ctx)
+    return newLogStream(ctx, cancel, func(emit func(LogLine) error) error {
+        for _, line := range lines {
+            if err := emit(line); err != nil {
+                return err
+            }
+        }
+        return nil
+    }), nil
+}
+
+// ExecContainer plays back the container's FakeExec whatever the command.
+// Like the daemon it refuses containers that aren't running.
+func (f *FakeRuntime) ExecContainer(ctx context.Context, containerID string, opts ExecOptions) (*ExecSession, error) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if err := f.record("ExecContainer", containerID+" "+strings.Join(opts.Cmd, " ")); err != nil {
+        return nil, err
+    }
+
+    i, err := f.find(containerID)
+    if err != nil {
+        return nil, err
+    }
+    c := f.Containers[i]
+    if c.State != "running" {
+        return nil, fmt.Errorf("container %s is not running", c.ID)
+    }
+
+    script := f.Execs[c.ID]
+    return &ExecSession{
+        ID:  "exec-" + c.ID,
+        Tty: opts.Tty,
+        stream: func(stdin io.Reader, stdout, stderr io.Writer) error {
+            // A terminal merges both streams
+            if opts.Tty {
+                stderr = stdout
+            }
+            if _, err := io.WriteString(stdout, script.Stdout); err != nil {
+                return err
+            }
+            _, err := io.WriteString(stderr, script.Stderr)
+            return err
+        },
+        resize:   func(width, height uint) error { return nil },
+        exitCode: func() (int, error) { return script.ExitCode, nil },
+        close:    func() {},
+    }, nil
+}
+
+func (f *FakeRuntime) setState(method, containerID, state, status string) error {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    if err := f.record(method, containerID); err != nil {
+        return err
+    }
+
+    i, err := f.find(containerID)
+    if err != nil {
+        return err
+    }
+    f.Containers[i].State = state
+    f.Containers[i].Status = status
+    return nil
+}
+
+// record must be called with f.mu held.
+func (f *FakeRuntime) record(method, arg string) error {
+    f.calls = append(f.calls, fmt.Sprintf("%s(%s)", method, arg))
+    return f.errs[method]
+}
+
+// find resolves an ID, ID prefix or name the same way the daemon does.
+// It must be called with f.mu held.
+func (f *FakeRuntime) find(containerID string) (int, error) {
+    for i, c := range f.Containers {
+        if containerID == "" {
+            break
+        }
+        if c.Name == containerID || strings.HasPrefix(c.ID, containerID) {
+            return i, nil
+        }
+    }
+    return -1, fmt.Errorf("No such container: %s", containerID)
+}
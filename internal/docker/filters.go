@@ -0,0 +1,128 @@
+package docker
+
+import (
+    "fmt"
+    "strings"
+
+    "github.com/docker/docker/api/types/filters"
+)
+
+const composeProjectLabel = "com.docker.compose.project"
+
+// filterKeys are the supported filter keys. "project" is shorthand for the
+// Docker Compose project label.
+var filterKeys = []string{"label", "status", "name", "ancestor", "network", "health", "project"}
+
+type Filter struct {
+    Key   string
+    Value string
+}
+
+func (f Filter) String() string {
+    return f.Key + "=" + f.Value
+}
+
+// ParseFilter parses a key=value filter as accepted by `docker ps --filter`.
+func ParseFilter(s string) (Filter, error) {
+    key, value, ok := strings.Cut(s, "=")
+    key = strings.ToLower(strings.TrimSpace(key))
+    if !ok || key == "" || value == "" {
+        return Filter{}, fmt.Errorf("invalid filter %q, expected key=value", s)
+    }
+
+    for _, k := range filterKeys {
+        if k == key {
+            return Filter{Key: key, Value: value}, nil
+        }
+    }
+    return Filter{}, fmt.Errorf("unsupported filter key %q (supported: %s)", key, strings.Join(filterKeys, ", "))
+}
+
+func ParseFilters(values []string) ([]Filter, error) {
+    var result []Filter
+    for _, v := range values {
+        f, err := ParseFilter(v)
+        if err != nil {
+            return nil, err
+        }
+        result = append(result, f)
+    }
+    return result, nil
+}
+
+// listAll reports whether stopped containers have to be listed. Filtering by
+// status only makes sense across all containers, as with `docker ps`.
+func (o ListOptions) listAll() bool {
+    if o.All {
+        return true
+    }
+    for _, f := range o.Filters {
+        if f.Key == "status" {
+            return true
+        }
+    }
+    return false
+}
+
+func filterArgs(fs []Filter) filters.Args {
+    args := filters.NewArgs()
+    for _, f := range fs {
+        key, value := f.Key, f.Value
+        if key == "project" {
+            key, value = "label", composeProjectLabel+"="+value
+        }
+        args.Add(key, value)
+    }
+    return args
+}
+
+// MatchFilters approximates the daemon's filtering. It backs FakeRuntime
+// and lets the TUI select among containers it has already listed.
+func MatchFilters(c ContainerInfo, fs []Filter) bool {
+    byKey := map[string][]Filter{}
+    for _, f := range fs {
+        byKey[f.Key] = append(byKey[f.Key], f)
+    }
+
+    for _, group := range byKey {
+        matched := false
+        for _, f := range group {
+            if matchFilter(c, f) {
+                matched = true
+                break
+            }
+        }
+        if !matched {
+            return false
+        }
+    }
+    return true
+}
+
+func matchFilter(c ContainerInfo, f Filter) bool {
+    switch f.Key {
+    case "label", "project":
+        key, value, hasValue := strings.Cut(f.Value, "=")
+        if f.Key == "project" {
+            key, value, hasValue = composeProjectLabel, f.Value, true
+        }
+        v, ok := c.Labels[key]
+        return ok && (!hasValue || v == value)
+    case "status":
+        return c.State == f.Value
+    case "name":
+        return strings.Contains(c.Name, f.Value)
+    case "ancestor":
+        return c.Image == f.Value || strings.HasPrefix(c.Image, f.Value+":")
+    case "network":
+        for _, n := range c.NetworkNames {
+            if n == f.Value {
+                return true
+            }
+        }
+        return false
+    case "health":
+        return c.Health == f.Value
+    }
+    return false
+}
@@ -0,0 +1,72 @@
+package docker
+
+import "testing"
+
+func TestParseFilter(t *testing.T) {
+    tests := []struct {
+        in      string
+        want    Filter
+        wantErr bool
+    }{
+        {"label=tier=db", Filter{Key: "label", Value: "tier=db"}, false},
+        {"Status=running", Filter{Key: "status", Value: "running"}, false},
+        {" health =starting", Filter{Key: "health", Value: "starting"}, false},
+        {"project=shop", Filter{Key: "project", Value: "shop"}, false},
+        {"name", Filter{}, true},
+        {"name=", Filter{}, true},
+        {"=web", Filter{}, true},
+        {"color=red", Filter{}, true},
+    }
+    for _, tt := range tests {
+        got, err := ParseFilter(tt.in)
+        if (err != nil) != tt.wantErr || got != tt.want {
+            t.Errorf("ParseFilter(%q) = %+v, %v; want %+v, error %v", tt.in, got, err, tt.want, tt.wantErr)
+        }
+    }
+}
+
+func TestMatchFilters(t *testing.T) {
+    c := ContainerInfo{
+        Name:         "shop-web-1",
+        Image:        "nginx:1.25",
+        State:        "running",
+        Health:       healthFromStatus("Up 2 seconds (health: starting)"),
+        NetworkNames: []string{"shop_default"},
+        Labels: map[string]string{
+            "tier":              "frontend",
+            composeProjectLabel: "shop",
+        },
+    }
+
+    tests := []struct {
+        filters []string
+        want    bool
+    }{
+        {nil, true},
+        {[]string{"health=starting"}, true},
+        {[]string{"health=healthy"}, false},
+        {[]string{"label=tier"}, true},
+        {[]string{"label=tier=frontend"}, true},
+        {[]string{"label=tier=backend"}, false},
+        {[]string{"project=shop"}, true},
+        {[]string{"project=other"}, false},
+        {[]string{"status=running"}, true},
+        {[]string{"name=web"}, true},
+        {[]string{"ancestor=nginx"}, true},
+        {[]string{"ancestor=nginx:1.25"}, true},
+        {[]string{"ancestor=ngi"}, false},
+        {[]string{"network=shop_default"}, true},
+        // The same key is ORed, different keys are ANDed
+        {[]string{"status=exited", "status=running"}, true},
+        {[]string{"status=running", "name=db"}, false},
+    }
+    for _, tt := range tests {
+        filters, err := ParseFilters(tt.filters)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if got := MatchFilters(c, filters); got != tt.want {
+            t.Errorf("%v: got %v, want %v", tt.filters, got, tt.want)
+        }
+    }
+}
@@ -0,0 +1,252 @@
+package docker
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "sort"
+    "strings"
+    "time"
+
+    "github.com/docker/docker/api/types"
+)
+
+// ContainerDetails is the inspect output of a container, flattened to what
+// the detail view and the inspect command show. Raw holds the daemon's full
+// JSON for anything else.
+type ContainerDetails struct {
+    ID      string    `json:"id" yaml:"id"`
+    Name    string    `json:"name" yaml:"name"`
+    Image   string    `json:"image" yaml:"image"`
+    Created time.Time `json:"created" yaml:"created"`
+
+    Config    ContainerConfig   `json:"config" yaml:"config"`
+    Mounts    []MountInfo       `json:"mounts" yaml:"mounts"`
+    Networks  []NetworkEndpoint `json:"networks" yaml:"networks"`
+    Ports     []PortBinding     `json:"ports" yaml:"ports"`
+    Restart   RestartPolicy     `json:"restart" yaml:"restart"`
+    Resources ResourceLimits    `json:"resources" yaml:"resources"`
+    State     ContainerState    `json:"state" yaml:"state"`
+
+    Raw json.RawMessage `json:"-" yaml:"-"`
+}
+
+type ContainerConfig struct {
+    Command    []string          `json:"command" yaml:"command"`
+    Entrypoint []string          `json:"entrypoint" yaml:"entrypoint"`
+    Env        []string          `json:"env" yaml:"env"`
+    Labels     map[string]string `json:"labels" yaml:"labels"`
+    WorkingDir string            `json:"working_dir" yaml:"working_dir"`
+    User       string            `json:"user" yaml:"user"`
+    Hostname   string            `json:"hostname" yaml:"hostname"`
+    Tty        bool              `json:"tty" yaml:"tty"`
+    StopSignal string            `json:"stop_signal" yaml:"stop_signal"`
+    // Healthcheck is the configured test command, if any
+    Healthcheck []string `json:"healthcheck" yaml:"healthcheck"`
+}
+
+type MountInfo struct {
+    Type        string `json:"type" yaml:"type"`
+    Name        string `json:"name" yaml:"name"`
+    Source      string `json:"source" yaml:"source"`
+    Destination string `json:"destination" yaml:"destination"`
+    Mode        string `json:"mode" yaml:"mode"`
+    RW          bool   `json:"rw" yaml:"rw"`
+}
+
+type NetworkEndpoint struct {
+    Name        string   `json:"name" yaml:"name"`
+    IPAddress   string   `json:"ip_address" yaml:"ip_address"`
+    IPv6Address string   `json:"ipv6_address" yaml:"ipv6_address"`
+    Gateway     string   `json:"gateway" yaml:"gateway"`
+    MacAddress  string   `json:"mac_address" yaml:"mac_address"`
+    Aliases     []string `json:"aliases" yaml:"aliases"`
+}
+
+// PortBinding publishes ContainerPort (e.g. "80/tcp") on the host. HostPort
+// is empty for ports that are only exposed.
+type PortBinding struct {
+    ContainerPort string `json:"container_port" yaml:"container_port"`
+    HostIP        string `json:"host_ip" yaml:"host_ip"`
+    HostPort      string `json:"host_port" yaml:"host_port"`
+}
+
+type RestartPolicy struct {
+    Name         string `json:"name" yaml:"name"`
+    MaxRetries   int    `json:"max_retries" yaml:"max_retries"`
+    RestartCount int    `json:"restart_count" yaml:"restart_count"`
+    AutoRemove   bool   `json:"auto_remove" yaml:"auto_remove"`
+}
+
+// ResourceLimits are the configured limits; zero means unlimited.
+type ResourceLimits struct {
+    CPUs       float64 `json:"cpus" yaml:"cpus"`
+    CPUShares  int64   `json:"cpu_shares" yaml:"cpu_shares"`
+    CpusetCpus string  `json:"cpuset_cpus" yaml:"cpuset_cpus"`
+    Memory     int64   `json:"memory_bytes" yaml:"memory_bytes"`
+    MemorySwap int64   `json:"memory_swap_bytes" yaml:"memory_swap_bytes"`
+    PidsLimit  int64   `json:"pids_limit" yaml:"pids_limit"`
+    Privileged bool    `json:"privileged" yaml:"privileged"`
+}
+
+type ContainerState struct {
+    Status     string    `json:"status" yaml:"status"`
+    Running    bool      `json:"running" yaml:"running"`
+    Paused     bool      `json:"paused" yaml:"paused"`
+    OOMKilled  bool      `json:"oom_killed" yaml:"oom_killed"`
+    Pid        int       `json:"pid" yaml:"pid"`
+    ExitCode   int       `json:"exit_code" yaml:"exit_code"`
+    Error      string    `json:"error" yaml:"error"`
+    StartedAt  time.Time `json:"started_at" yaml:"started_at"`
+    FinishedAt time.Time `json:"finished_at" yaml:"finished_at"`
+
+    Health *HealthState `json:"health,omitempty" yaml:"health,omitempty"`
+}
+
+type HealthState struct {
+    Status        string        `json:"status" yaml:"status"`
+    FailingStreak int           `json:"failing_streak" yaml:"failing_streak"`
+    Log           []HealthCheck `json:"log" yaml:"log"`
+}
+
+type HealthCheck struct {
+    Start    time.Time `json:"start" yaml:"start"`
+    End      time.Time `json:"end" yaml:"end"`
+    ExitCode int       `json:"exit_code" yaml:"exit_code"`
+    Output   string    `json:"output" yaml:"output"`
+}
+
+func (d *DockerClient) InspectContainer(containerID string) (*ContainerDetails, error) {
+    ctx := context.Background()
+    info, raw, err := d.cli.ContainerInspectWithRaw(ctx, containerID, false)
+    if err != nil {
+        return nil, err
+    }
+
+    details := newContainerDetails(info)
+    var indented bytes.Buffer
+    if err := json.Indent(&indented, raw, "", "  "); err == nil {
+        raw = indented.Bytes()
+    }
+    details.Raw = raw
+    return details, nil
+}
+
+func newContainerDetails(info types.ContainerJSON) *ContainerDetails {
+    d := &ContainerDetails{}
+    if info.ContainerJSONBase != nil {
+        d.ID = info.ID
+        d.Name = strings.TrimPrefix(info.Name, "/")
+        d.Image = info.Image
+        d.Created, _ = time.Parse(time.RFC3339Nano, info.Created)
+        d.Restart.RestartCount = info.RestartCount
+
+        if s := info.State; s != nil {
+            d.State = ContainerState{
+                Status:    s.Status,
+                Running:   s.Running,
+                Paused:    s.Paused,
+                OOMKilled: s.OOMKilled,
+                Pid:       s.Pid,
+                ExitCode:  s.ExitCode,
+                Error:     s.Error,
+            }
+            d.State.StartedAt, _ = time.Parse(time.RFC3339Nano, s.StartedAt)
+            d.State.FinishedAt, _ = time.Parse(time.RFC3339Nano, s.FinishedAt)
+            if h := s.Health; h != nil {
+                d.State.Health = &HealthState{Status: h.Status, FailingStreak: h.FailingStreak}
+                for _, r := range h.Log {
+                    if r == nil {
+                        continue
+                    }
+                    d.State.Health.Log = append(d.State.Health.Log, HealthCheck{
+                        Start:    r.Start,
+                        End:      r.End,
+                        ExitCode: r.ExitCode,
+                        Output:   strings.TrimSpace(r.Output),
+                    })
+                }
+            }
+        }
+
+        if hc := info.HostConfig; hc != nil {
+            d.Restart.Name = hc.RestartPolicy.Name
+            d.Restart.MaxRetries = hc.RestartPolicy.MaximumRetryCount
+            d.Restart.AutoRemove = hc.AutoRemove
+            d.Resources = ResourceLimits{
+                CPUs:       float64(hc.NanoCPUs) / 1e9,
+                CPUShares:  hc.CPUShares,
+                CpusetCpus: hc.CpusetCpus,
+                Memory:     hc.Memory,
+                MemorySwap: hc.MemorySwap,
+                Privileged: hc.Privileged,
+            }
+            if hc.PidsLimit != nil {
+                d.Resources.PidsLimit = *hc.PidsLimit
+            }
+        }
+    }
+
+    if c := info.Config; c != nil {
+        d.Config = ContainerConfig{
+            Command:    c.Cmd,
+            Entrypoint: c.Entrypoint,
+            Env:        c.Env,
+            Labels:     c.Labels,
+            WorkingDir: c.WorkingDir,
+            User:       c.User,
+            Hostname:   c.Hostname,
+            Tty:        c.Tty,
+            StopSignal: c.StopSignal,
+        }
+        if c.Healthcheck != nil {
+            d.Config.Healthcheck = c.Healthcheck.Test
+        }
+    }
+
+    for _, m := range info.Mounts {
+        d.Mounts = append(d.Mounts, MountInfo{
+            Type:        string(m.Type),
+            Name:        m.Name,
+            Source:      m.Source,
+            Destination: m.Destination,
+            Mode:        m.Mode,
+            RW:          m.RW,
+        })
+    }
+
+    if ns := info.NetworkSettings; ns != nil {
+        for name, ep := range ns.Networks {
+            if ep == nil {
+                continue
+            }
+            d.Networks = append(d.Networks, NetworkEndpoint{
+                Name:        name,
+                IPAddress:   ep.IPAddress,
+                IPv6Address: ep.GlobalIPv6Address,
+                Gateway:     ep.Gateway,
+                MacAddress:  ep.MacAddress,
+                Aliases:     ep.Aliases,
+            })
+        }
+        sort.Slice(d.Networks, func(i, j int) bool { return d.Networks[i].Name < d.Networks[j].Name })
+
+        for port, bindings := range ns.Ports {
+            if len(bindings) == 0 {
+                d.Ports = append(d.Ports, PortBinding{ContainerPort: string(port)})
+                continue
+            }
+            for _, b := range bindings {
+                d.Ports = append(d.Ports, PortBinding{ContainerPort: string(port), HostIP: b.HostIP, HostPort: b.HostPort})
+            }
+        }
+        sort.Slice(d.Ports, func(i, j int) bool {
+            if d.Ports[i].ContainerPort != d.Ports[j].ContainerPort {
+                return d.Ports[i].ContainerPort < d.Ports[j].ContainerPort
+            }
+            return d.Ports[i].HostIP < d.Ports[j].HostIP
+        })
+    }
+
+    return d
+}
@@ -0,0 +1,247 @@
+package docker
+
+import (
+    "bytes"
+    "context"
+    "io"
+    "strings"
+    "time"
+
+    "github.com/docker/docker/api/types"
+    "github.com/docker/docker/pkg/stdcopy"
+)
+
+const (
+    StreamStdout = "stdout"
+    StreamStderr = "stderr"
+)
+
+type LogOptions struct {
+    // Tail is the number of lines from the end to show, or "all"
+    Tail string
+
+    // Since and Until accept RFC 3339 timestamps, Unix timestamps or
+    // durations relative to now such as "10m"
+    Since string
+    Until string
+
+    // Timestamps is a display preference; LogLine.Timestamp is always set
+    Timestamps bool
+    Follow     bool
+
+    // Stdout and Stderr select the streams to show. Leaving both unset
+    // shows both.
+    Stdout bool
+    Stderr bool
+}
+
+// LogLine is a single line of container output with the stream it was
+// written to and the time the daemon received it. Container is only set
+// on lines from MergeLogs.
+type LogLine struct {
+    Container string    `json:"container,omitempty"`
+    Stream    string    `json:"stream"`
+    Timestamp time.Time `json:"timestamp"`
+    Text      string    `json:"text"`
+}
+
+// Format renders the line, prefixed with its timestamp if requested.
+func (l LogLine) Format(timestamps bool) string {
+    if timestamps && !l.Timestamp.IsZero() {
+        return l.Timestamp.Format(time.RFC3339Nano) + " " + l.Text
+    }
+    return l.Text
+}
+
+func (o LogOptions) dockerOptions() types.ContainerLogsOptions {
+    stdout, stderr := o.Stdout, o.Stderr
+    if !stdout && !stderr {
+        stdout, stderr = true, true
+    }
+    return types.ContainerLogsOptions{
+        ShowStdout: stdout,
+        ShowStderr: stderr,
+        Since:      o.Since,
+        Until:      o.Until,
+        // Always requested so every LogLine carries its timestamp
+        Timestamps: true,
+        Follow:     o.Follow,
+        Tail:       o.Tail,
+    }
+}
+
+// GetContainerLogs returns the logs matching opts. Follow is ignored, use
+// StreamLogs to keep reading new output.
+func (d *DockerClient) GetContainerLogs(containerID string, opts LogOptions) ([]LogLine, error) {
+    ctx := context.Background()
+    tty, err := d.hasTTY(ctx, containerID)
+    if err != nil {
+        return nil, err
+    }
+
+    opts.Follow = false
+    out, err := d.cli.ContainerLogs(ctx, containerID, opts.dockerOptions())
+    if err != nil {
+        return nil, err
+    }
+    defer out.Close()
+
+    var lines []LogLine
+    err = readLogs(out, tty, func(line LogLine) error {
+        lines = append(lines, line)
+        return nil
+    })
+    return lines, err
+}
+
+// LogStream delivers lines from StreamLogs until the logs end or it is
+// closed.
+type LogStream struct {
+    Lines <-chan LogLine
+
+    cancel context.CancelFunc
+    done   chan struct{}
+    err    error
+}
+
+// Err returns the error that ended the stream, if any. It is only valid
+// once Lines has been closed.
+func (s *LogStream) Err() error {
+    return s.err
+}
+
+// Close stops the stream and waits for it to shut down.
+func (s *LogStream) Close() {
+    s.cancel()
+    <-s.done
+}
+
+func newLogStream(ctx context.Context, cancel context.CancelFunc, read func(emit func(LogLine) error) error) *LogStream {
+    lines := make(chan LogLine, 64)
+    s := &LogStream{
+        Lines:  lines,
+        cancel: cancel,
+        done:   make(chan struct{}),
+    }
+
+    go func() {
+        defer close(s.done)
+        defer close(lines)
+
+        err := read(func(line LogLine) error {
+            select {
+            case lines <- line:
+                return nil
+            case <-ctx.Done():
+                return ctx.Err()
+            }
+        })
+        // Being closed is not a failure
+        if ctx.Err() == nil {
+            s.err = err
+        }
+    }()
+
+    return s
+}
+
+// StreamLogs streams the logs matching opts. With Follow set it stays open
+// until the container stops, ctx is cancelled or the stream is closed.
+func (d *DockerClient) StreamLogs(ctx context.Context, containerID string, opts LogOptions) (*LogStream, error) {
+    tty, err := d.hasTTY(ctx, containerID)
+    if err != nil {
+        return nil, err
+    }
+
+    ctx, cancel := context.WithCancel(ctx)
+    body, err := d.cli.ContainerLogs(ctx, containerID, opts.dockerOptions())
+    if err != nil {
+        cancel()
+        return nil, err
+    }
+
+    return newLogStream(ctx, cancel, func(emit func(LogLine) error) error {
+        defer body.Close()
+        return readLogs(body, tty, emit)
+    }), nil
+}
+
+// hasTTY reports whether the container was started with a TTY, in which case
+// its logs are a single raw stream instead of multiplexed frames.
+func (d *DockerClient) hasTTY(ctx context.Context, containerID string) (bool, error) {
+    info, err := d.cli.ContainerInspect(ctx, containerID)
+    if err != nil {
+        return false, err
+    }
+    return info.Config != nil && info.Config.Tty, nil
+}
+
+// readLogs splits a log stream into lines, demultiplexing the 8-byte stdout
+// and stderr frame headers unless the container has a TTY.
+func readLogs(r io.Reader, tty bool, emit func(LogLine) error) error {
+    stdout := &lineWriter{stream: StreamStdout, emit: emit}
+    stderr := &lineWriter{stream: StreamStderr, emit: emit}
+
+    var err error
+    if tty {
+        _, err = io.Copy(stdout, r)
+    } else {
+        _, err = stdcopy.StdCopy(stdout, stderr, r)
+    }
+
+    if flushErr := stdout.flush(); err == nil {
+        err = flushErr
+    }
+    if flushErr := stderr.flush(); err == nil {
+        err = flushErr
+    }
+    return err
+}
+
+// lineWriter turns the writes of one stream into LogLines.
+type lineWriter struct {
+    stream string
+    emit   func(LogLine) error
+    buf    []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+    w.buf = append(w.buf, p...)
+    for {
+        i := bytes.IndexByte(w.buf, '\n')
+        if i < 0 {
+            return len(p), nil
+        }
+        line := string(w.buf[:i])
+        w.buf = w.buf[i+1:]
+        if err := w.emit(parseLogLine(w.stream, line)); err != nil {
+            return 0, err
+        }
+    }
+}
+
+// flush emits a trailing line that had no newline.
+func (w *lineWriter) flush() error {
+    if len(w.buf) == 0 {
+        return nil
+    }
+    line := string(w.buf)
+    w.buf = nil
+    return w.emit(parseLogLine(w.stream, line))
+}
+
+func parseLogLine(stream, line string) LogLine {
+    line = strings.TrimSuffix(line, "\r")
+    result := LogLine{Stream: stream, Text: line}
+
+    ts, text, ok := strings.Cut(line, " ")
+    if !ok {
+        // An empty line only carries the timestamp
+        ts, text = line, ""
+    }
+    if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+        result.Timestamp = t
+        result.Text = text
+    }
+    return result
+}
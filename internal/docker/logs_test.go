@@ -0,0 +1,86 @@
+package docker
+
+import (
+    "bytes"
+    "testing"
+    "time"
+
+    "github.com/docker/docker/pkg/stdcopy"
+)
+
+func collect(t *testing.T, r *bytes.Buffer, tty bool) []LogLine {
+    t.Helper()
+    var lines []LogLine
+    err := readLogs(r, tty, func(line LogLine) error {
+        lines = append(lines, line)
+        return nil
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+    return lines
+}
+
+func TestReadLogsDemultiplexes(t *testing.T) {
+    var buf bytes.Buffer
+    stdout := stdcopy.NewStdWriter(&buf, stdcopy.Stdout)
+    stderr := stdcopy.NewStdWriter(&buf, stdcopy.Stderr)
+    stdout.Write([]byte("first\nsplit "))
+    stderr.Write([]byte("oops\n"))
+    stdout.Write([]byte("line\r\n"))
+    stdout.Write([]byte("no newline"))
+
+    want := []LogLine{
+        {Stream: StreamStdout, Text: "first"},
+        {Stream: StreamStderr, Text: "oops"},
+        {Stream: StreamStdout, Text: "split line"},
+        {Stream: StreamStdout, Text: "no newline"},
+    }
+    got := collect(t, &buf, false)
+    if len(got) != len(want) {
+        t.Fatalf("got %+v, want %+v", got, want)
+    }
+    for i := range want {
+        if got[i] != want[i] {
+            t.Errorf("line %d = %+v, want %+v", i, got[i], want[i])
+        }
+    }
+}
+
+func TestReadLogsTTY(t *testing.T) {
+    // A TTY stream has no frame headers; everything is stdout
+    buf := bytes.NewBufferString("one\r\ntwo\n")
+
+    got := collect(t, buf, true)
+    if len(got) != 2 || got[0].Text != "one" || got[1].Text != "two" {
+        t.Fatalf("got %+v", got)
+    }
+    for _, line := range got {
+        if line.Stream != StreamStdout {
+            t.Errorf("stream = %s, want stdout", line.Stream)
+        }
+    }
+}
+
+func TestParseLogLine(t *testing.T) {
+    ts := time.Date(2024, 1, 2, 13, 23, 37, 123456789, time.UTC)
+
+    tests := []struct {
+        in       string
+        wantText string
+        wantTime time.Time
+    }{
+        {"2024-01-02T13:23:37.123456789Z hello world", "hello world", ts},
+        {"2024-01-02T13:23:37.123456789Z", "", ts},
+        {"2024-01-02T13:23:37.123456789Z \r", "", ts},
+        {"hello world", "hello world", time.Time{}},
+        {"not-a-time message", "not-a-time message", time.Time{}},
+        {"", "", time.Time{}},
+    }
+    for _, tt := range tests {
+        got := parseLogLine(StreamStdout, tt.in)
+        if got.Text != tt.wantText || !got.Timestamp.Equal(tt.wantTime) {
+            t.Errorf("parseLogLine(%q) = %q at %v, want %q at %v", tt.in, got.Text, got.Timestamp, tt.wantText, tt.wantTime)
+        }
+    }
+}
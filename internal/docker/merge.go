@@ -0,0 +1,151 @@
+package docker
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "sort"
+    "time"
+)
+
+// mergeWindow is how long MergeLogs holds a followed line before emitting
+// it, giving slower streams a chance to deliver earlier lines first.
+const mergeWindow = 200 * time.Millisecond
+
+// MergeLogs streams the logs of several containers as one, ordered by
+// timestamp and with LogLine.Container set to the container's name. Without
+// Follow the stream ends once every container's logs have been read.
+func MergeLogs(ctx context.Context, runtime ContainerRuntime, containers []ContainerInfo, opts LogOptions) (*LogStream, error) {
+    ctx, cancel := context.WithCancel(ctx)
+
+    streams := make([]*LogStream, 0, len(containers))
+    for _, c := range containers {
+        stream, err := runtime.StreamLogs(ctx, c.ID, opts)
+        if err != nil {
+            cancel()
+            for _, s := range streams {
+                s.Close()
+            }
+            return nil, fmt.Errorf("%s: %w", c.Name, err)
+        }
+        streams = append(streams, stream)
+    }
+
+    return newLogStream(ctx, cancel, func(emit func(LogLine) error) error {
+        defer func() {
+            for _, s := range streams {
+                s.Close()
+            }
+        }()
+        return mergeStreams(ctx, containers, streams, emit)
+    }), nil
+}
+
+type pendingLine struct {
+    line     LogLine
+    received time.Time
+}
+
+func mergeStreams(ctx context.Context, containers []ContainerInfo, streams []*LogStream, emit func(LogLine) error) error {
+    type sourceEnd struct {
+        name string
+        err  error
+    }
+
+    lines := make(chan LogLine)
+    // Buffered so the forwarders can always finish, even after an early
+    // return
+    ended := make(chan sourceEnd, len(streams))
+    for i, stream := range streams {
+        name := containers[i].Name
+        go func(stream *LogStream) {
+            for line := range stream.Lines {
+                line.Container = name
+                select {
+                case lines <- line:
+                case <-ctx.Done():
+                }
+            }
+            ended <- sourceEnd{name: name, err: stream.Err()}
+        }(stream)
+    }
+
+    ticker := time.NewTicker(mergeWindow / 4)
+    defer ticker.Stop()
+
+    var (
+        pending []pendingLine
+        errs    []error
+        open    = len(streams)
+    )
+
+    // flush emits the pending lines received before cutoff in timestamp
+    // order. A zero cutoff flushes everything.
+    flush := func(cutoff time.Time) error {
+        sort.SliceStable(pending, func(i, j int) bool {
+            return pending[i].line.Timestamp.Before(pending[j].line.Timestamp)
+        })
+        keep := pending[:0]
+        for _, p := range pending {
+            if !cutoff.IsZero() && p.received.After(cutoff) {
+                keep = append(keep, p)
+                continue
+            }
+            if err := emit(p.line); err != nil {
+                return err
+            }
+        }
+        pending = keep
+        return nil
+    }
+
+    for open > 0 {
+        select {
+        case line := <-lines:
+            pending = append(pending, pendingLine{line: line, received: time.Now()})
+
+        case end := <-ended:
+            open--
+            if end.err != nil {
+                errs = append(errs, fmt.Errorf("%s: %w", end.name, end.err))
+            }
+
+        case now := <-ticker.C:
+            if err := flush(now.Add(-mergeWindow)); err != nil {
+                return err
+            }
+
+        case <-ctx.Done():
+            return ctx.Err()
+        }
+    }
+
+    if err := flush(time.Time{}); err != nil {
+        return err
+    }
+    return errors.Join(errs...)
+}
+
+// CollectLogs reads the logs of several containers with GetContainerLogs
+// and returns them as one list ordered by timestamp. Lines carry their
+// container's name when there is more than one container.
+func CollectLogs(runtime ContainerRuntime, containers []ContainerInfo, opts LogOptions) ([]LogLine, error) {
+    var all []LogLine
+    for _, c := range containers {
+        lines, err := runtime.GetContainerLogs(c.ID, opts)
+        if err != nil {
+            return nil, fmt.Errorf("%s: %w", c.Name, err)
+        }
+        if len(containers) > 1 {
+            for i := range lines {
+                lines[i].Container = c.Name
+            }
+        }
+        all = append(all, lines...)
+    }
+
+    sort.SliceStable(all, func(i, j int) bool {
+        return all[i].Timestamp.Before(all[j].Timestamp)
+    })
+    return all, nil
+}
@@ -0,0 +1,81 @@
+package docker
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestMergeLogsOrdersByTimestamp(t *testing.T) {
+    base := time.Date(2024, 1, 2, 13, 0, 0, 0, time.UTC)
+    at := func(sec int) time.Time { return base.Add(time.Duration(sec) * time.Second) }
+
+    fake := NewFakeRuntime(
+        ContainerInfo{ID: "aaaaaaaaaaaa", Name: "web", State: "running"},
+        ContainerInfo{ID: "bbbbbbbbbbbb", Name: "db", State: "running"},
+    )
+    fake.Logs["aaaaaaaaaaaa"] = []LogLine{
+        {Stream: StreamStdout, Text: "web 1", Timestamp: at(1)},
+        {Stream: StreamStdout, Text: "web 4", Timestamp: at(4)},
+        {Stream: StreamStderr, Text: "web 5", Timestamp: at(5)},
+    }
+    fake.Logs["bbbbbbbbbbbb"] = []LogLine{
+        {Stream: StreamStdout, Text: "db 2", Timestamp: at(2)},
+        {Stream: StreamStdout, Text: "db 3", Timestamp: at(3)},
+        {Stream: StreamStdout, Text: "db 6", Timestamp: at(6)},
+    }
+
+    containers, err := fake.ListContainers(ListOptions{SkipStats: true})
+    if err != nil {
+        t.Fatal(err)
+    }
+    stream, err := MergeLogs(context.Background(), fake, containers, LogOptions{Tail: "all"})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    var got []string
+    for line := range stream.Lines {
+        got = append(got, line.Container+":"+line.Text)
+    }
+    if err := stream.Err(); err != nil {
+        t.Fatal(err)
+    }
+
+    want := "web:web 1,db:db 2,db:db 3,web:web 4,web:web 5,db:db 6"
+    if strings.Join(got, ",") != want {
+        t.Errorf("got  %s\nwant %s", strings.Join(got, ","), want)
+    }
+}
+
+func TestMergeLogsNamesFailingContainer(t *testing.T) {
+    fake := NewFakeRuntime(ContainerInfo{ID: "aaaaaaaaaaaa", Name: "web", State: "running"})
+    fake.Fail("GetContainerLogs", errors.New("boom"))
+
+    containers, _ := fake.ListContainers(ListOptions{SkipStats: true})
+    _, err := MergeLogs(context.Background(), fake, containers, LogOptions{Tail: "all"})
+    if err == nil || err.Error() != "web: boom" {
+        t.Errorf("err = %v, want web: boom", err)
+    }
+}
+
+func TestCollectLogsOrdersByTimestamp(t *testing.T) {
+    base := time.Date(2024, 1, 2, 13, 0, 0, 0, time.UTC)
+    fake := NewFakeRuntime(
+        ContainerInfo{ID: "aaaaaaaaaaaa", Name: "web", State: "running"},
+        ContainerInfo{ID: "bbbbbbbbbbbb", Name: "db", State: "running"},
+    )
+    fake.Logs["aaaaaaaaaaaa"] = []LogLine{{Text: "second", Timestamp: base.Add(time.Second)}}
+    fake.Logs["bbbbbbbbbbbb"] = []LogLine{{Text: "first", Timestamp: base}}
+
+    containers, _ := fake.ListContainers(ListOptions{SkipStats: true})
+    lines, err := CollectLogs(fake, containers, LogOptions{Tail: "all"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(lines) != 2 || lines[0].Container != "db" || lines[1].Container != "web" {
+        t.Errorf("got %+v, want db's line before web's", lines)
+    }
+}
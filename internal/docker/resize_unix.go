@@ -0,0 +1,33 @@
+//go:build !windows
+
+package docker
+
+import (
+    "os"
+    "os/signal"
+    "syscall"
+)
+
+// watchResize calls resize whenever the terminal window changes size, until
+// stop is called.
+func watchResize(fd int, resize func()) (stop func()) {
+    sigs := make(chan os.Signal, 1)
+    signal.Notify(sigs, syscall.SIGWINCH)
+    done := make(chan struct{})
+
+    go func() {
+        for {
+            select {
+            case <-sigs:
+                resize()
+            case <-done:
+                return
+            }
+        }
+    }()
+
+    return func() {
+        signal.Stop(sigs)
+        close(done)
+    }
+}
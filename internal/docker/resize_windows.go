@@ -0,0 +1,38 @@
+//go:build windows
+
+package docker
+
+import (
+    "time"
+
+    "golang.org/x/term"
+)
+
+// watchResize calls resize whenever the console changes size, until stop is
+// called. Windows has no SIGWINCH, so the size is polled.
+func watchResize(fd int, resize func()) (stop func()) {
+    done := make(chan struct{})
+
+    go func() {
+        ticker := time.NewTicker(250 * time.Millisecond)
+        defer ticker.Stop()
+        width, height, _ := term.GetSize(fd)
+        for {
+            select {
+            case <-ticker.C:
+                w, h, err := term.GetSize(fd)
+                if err != nil || (w == width && h == height) {
+                    continue
+                }
+                width, height = w, h
+                resize()
+            case <-done:
+                return
+            }
+        }
+    }()
+
+    return func() {
+        close(done)
+    }
+}
@@ -0,0 +1,31 @@
+package docker
+
+import "context"
+
+// ContainerRuntime is the set of container operations used by the commands
+// and the TUI. DockerClient talks to a real daemon, FakeRuntime keeps
+// everything in memory so output can be tested without one.
+type ContainerRuntime interface {
+    Ping(ctx context.Context) (ServerInfo, error)
+    ListContainers(opts ListOptions) ([]ContainerInfo, error)
+    GetContainerStats(containerID string) (*ContainerStats, error)
+    InspectContainer(containerID string) (*ContainerDetails, error)
+    WatchStats(ctx context.Context) StatsSource
+    WatchEvents(ctx context.Context) <-chan ContainerEvent
+    StartContainer(containerID string) error
+    StopContainer(containerID string, opts StopOptions) error
+    RestartContainer(containerID string, opts StopOptions) error
+    PauseContainer(containerID string) error
+    UnpauseContainer(containerID string) error
+    KillContainer(containerID, signal string) error
+    RenameContainer(containerID, newName string) error
+    RemoveContainer(containerID string, opts RemoveOptions) error
+    GetContainerLogs(containerID string, opts LogOptions) ([]LogLine, error)
+    StreamLogs(ctx context.Context, containerID string, opts LogOptions) (*LogStream, error)
+    ExecContainer(ctx context.Context, containerID string, opts ExecOptions) (*ExecSession, error)
+}
+
+var (
+    _ ContainerRuntime = (*DockerClient)(nil)
+    _ ContainerRuntime = (*FakeRuntime)(nil)
+)
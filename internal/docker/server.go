@@ -0,0 +1,43 @@
+package docker
+
+import (
+    "context"
+    "time"
+)
+
+// pingTimeout bounds a health check so an unresponsive daemon is reported
+// as down instead of hanging.
+const pingTimeout = 5 * time.Second
+
+// ServerInfo describes the daemon answering a Ping.
+type ServerInfo struct {
+    Version    string `json:"version"`
+    APIVersion string `json:"api_version"`
+    OS         string `json:"os"`
+    Arch       string `json:"arch"`
+}
+
+func (s ServerInfo) String() string {
+    return "Docker " + s.Version + " (API " + s.APIVersion + ", " + s.OS + "/" + s.Arch + ")"
+}
+
+// Ping checks that the daemon is reachable and reports its version. Use
+// IsConnectionError to tell a daemon that's down from other failures.
+func (d *DockerClient) Ping(ctx context.Context) (ServerInfo, error) {
+    ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+    defer cancel()
+
+    if _, err := d.cli.Ping(ctx); err != nil {
+        return ServerInfo{}, err
+    }
+    v, err := d.cli.ServerVersion(ctx)
+    if err != nil {
+        return ServerInfo{}, err
+    }
+    return ServerInfo{
+        Version:    v.Version,
+        APIVersion: v.APIVersion,
+        OS:         v.Os,
+        Arch:       v.Arch,
+    }, nil
+}
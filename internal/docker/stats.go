@@ -0,0 +1,53 @@
+package docker
+
+import (
+    "strings"
+
+    "github.com/docker/docker/api/types"
+)
+
+// calculateCPUPercent mirrors `docker stats`. OnlineCPUs is the only CPU
+// count reported on cgroup v2 hosts, where PercpuUsage is always empty.
+func calculateCPUPercent(cur, pre types.CPUStats) float64 {
+    cpuDelta := float64(cur.CPUUsage.TotalUsage) - float64(pre.CPUUsage.TotalUsage)
+    systemDelta := float64(cur.SystemUsage) - float64(pre.SystemUsage)
+
+    onlineCPUs := float64(cur.OnlineCPUs)
+    if onlineCPUs == 0 {
+        onlineCPUs = float64(len(cur.CPUUsage.PercpuUsage))
+    }
+
+    if systemDelta > 0 && cpuDelta > 0 {
+        return (cpuDelta / systemDelta) * onlineCPUs * 100
+    }
+    return 0
+}
+
+// calculateMemUsage returns memory usage without the reclaimable page cache.
+// cgroup v1 reports it as total_inactive_file, cgroup v2 as inactive_file,
+// and old kernels only as cache.
+func calculateMemUsage(mem types.MemoryStats) uint64 {
+    for _, key := range []string{"total_inactive_file", "inactive_file", "cache"} {
+        if v, ok := mem.Stats[key]; ok {
+            if v < mem.Usage {
+                return mem.Usage - v
+            }
+            return mem.Usage
+        }
+    }
+    return mem.Usage
+}
+
+// calculateBlockIO sums the bytes read and written across all devices.
+// cgroup v1 reports the operations capitalised, cgroup v2 in lower case.
+func calculateBlockIO(blkio types.BlkioStats) (read, write uint64) {
+    for _, entry := range blkio.IoServiceBytesRecursive {
+        switch strings.ToLower(entry.Op) {
+        case "read":
+            read += entry.Value
+        case "write":
+            write += entry.Value
+        }
+    }
+    return read, write
+}
@@ -0,0 +1,123 @@
+package docker
+
+import (
+    "math"
+    "testing"
+    "time"
+
+    "github.com/docker/docker/api/types"
+)
+
+func TestCalculateCPUPercent(t *testing.T) {
+    cpu := func(total, system uint64, online uint32, percpu int) types.CPUStats {
+        return types.CPUStats{
+            CPUUsage:    types.CPUUsage{TotalUsage: total, PercpuUsage: make([]uint64, percpu)},
+            SystemUsage: system,
+            OnlineCPUs:  online,
+        }
+    }
+
+    tests := []struct {
+        name     string
+        cur, pre types.CPUStats
+        want     float64
+    }{
+        {"one of four CPUs busy", cpu(300, 4000, 4, 0), cpu(200, 3600, 4, 0), 100},
+        {"half a CPU", cpu(150, 2000, 1, 0), cpu(100, 1900, 1, 0), 50},
+        {"cgroup v1 counts per-CPU usage", cpu(300, 4000, 0, 2), cpu(200, 3600, 0, 2), 50},
+        {"no system progress", cpu(300, 4000, 4, 0), cpu(200, 4000, 4, 0), 0},
+        {"counter went backwards", cpu(100, 4000, 4, 0), cpu(200, 3600, 4, 0), 0},
+        {"first sample", cpu(300, 4000, 4, 0), types.CPUStats{}, 30},
+    }
+    for _, tt := range tests {
+        if got := calculateCPUPercent(tt.cur, tt.pre); math.Abs(got-tt.want) > 1e-9 {
+            t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+        }
+    }
+}
+
+func TestCalculateMemUsage(t *testing.T) {
+    tests := []struct {
+        name string
+        mem  types.MemoryStats
+        want uint64
+    }{
+        {"cgroup v1", types.MemoryStats{Usage: 1000, Stats: map[string]uint64{"total_inactive_file": 300, "cache": 500}}, 700},
+        {"cgroup v2", types.MemoryStats{Usage: 1000, Stats: map[string]uint64{"inactive_file": 200}}, 800},
+        {"old kernel", types.MemoryStats{Usage: 1000, Stats: map[string]uint64{"cache": 400}}, 600},
+        {"cache larger than usage", types.MemoryStats{Usage: 100, Stats: map[string]uint64{"inactive_file": 200}}, 100},
+        {"no breakdown", types.MemoryStats{Usage: 1000}, 1000},
+    }
+    for _, tt := range tests {
+        if got := calculateMemUsage(tt.mem); got != tt.want {
+            t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+        }
+    }
+}
+
+func TestCalculateBlockIO(t *testing.T) {
+    blkio := types.BlkioStats{IoServiceBytesRecursive: []types.BlkioStatEntry{
+        // cgroup v1 on two devices
+        {Major: 8, Op: "Read", Value: 100},
+        {Major: 8, Op: "Write", Value: 10},
+        {Major: 8, Op: "Total", Value: 110},
+        {Major: 9, Op: "Read", Value: 50},
+        // cgroup v2
+        {Major: 259, Op: "read", Value: 1},
+        {Major: 259, Op: "write", Value: 2},
+    }}
+
+    read, write := calculateBlockIO(blkio)
+    if read != 151 || write != 12 {
+        t.Errorf("got %d read, %d written; want 151 and 12", read, write)
+    }
+}
+
+func TestNewContainerStatsSumsInterfaces(t *testing.T) {
+    v := &types.StatsJSON{
+        Networks: map[string]types.NetworkStats{
+            "eth0": {RxBytes: 1000, TxBytes: 100},
+            "eth1": {RxBytes: 24, TxBytes: 20},
+        },
+    }
+    v.PidsStats.Current = 7
+
+    stats := newContainerStats(v, types.CPUStats{})
+    if stats.NetRx != 1024 || stats.NetTx != 120 {
+        t.Errorf("totals = %d/%d, want 1024/120", stats.NetRx, stats.NetTx)
+    }
+    if len(stats.Networks) != 2 || stats.Networks["eth1"].RxBytes != 24 {
+        t.Errorf("per interface = %+v", stats.Networks)
+    }
+    if stats.PIDs != 7 {
+        t.Errorf("PIDs = %d, want 7", stats.PIDs)
+    }
+}
+
+func TestComputeRates(t *testing.T) {
+    at := time.Date(2024, 1, 2, 13, 0, 0, 0, time.UTC)
+    prev := &ContainerStats{NetRx: 1000, NetTx: 500, BlockRead: 4096, UpdatedAt: at}
+
+    cur := &ContainerStats{NetRx: 5096, NetTx: 500, BlockRead: 4096, BlockWrite: 2048, UpdatedAt: at.Add(2 * time.Second)}
+    cur.computeRates(prev)
+    if !cur.HasRates || cur.NetRxRate != 2048 || cur.NetTxRate != 0 || cur.BlockWriteRate != 1024 {
+        t.Errorf("rates = %+v", cur)
+    }
+    if cur.Network != "↓2.0KiB/s ↑0B/s" {
+        t.Errorf("Network = %q", cur.Network)
+    }
+
+    // Counters start over when the container restarts
+    restarted := &ContainerStats{NetRx: 10, UpdatedAt: at.Add(time.Second)}
+    restarted.computeRates(prev)
+    if restarted.NetRxRate != 0 {
+        t.Errorf("rate after a restart = %v, want 0", restarted.NetRxRate)
+    }
+
+    // Samples taken at the same time give no rate at all
+    same := &ContainerStats{NetRx: 2000, UpdatedAt: at}
+    same.computeRates(prev)
+    if same.HasRates {
+        t.Errorf("rates computed over no time: %+v", same)
+    }
+}
@@ -0,0 +1,47 @@
+package docker
+
+import (
+    "fmt"
+    "math"
+)
+
+// FormatBytes renders a byte count with binary units, e.g. "12.3MiB".
+func FormatBytes(b uint64) string {
+    const unit = 1024
+    if b < unit {
+        return fmt.Sprintf("%dB", b)
+    }
+    value, exp := scaleUnits(float64(b)/unit, len("KMGTPE"))
+    return fmt.Sprintf("%.1f%ciB", value, "KMGTPE"[exp])
+}
+
+// FormatRate renders a throughput in bytes per second, e.g. "1.5KiB/s".
+func FormatRate(bytesPerSec float64) string {
+    const unit = 1024.0
+    if math.Round(bytesPerSec) < unit {
+        return fmt.Sprintf("%.0fB/s", bytesPerSec)
+    }
+    units := []string{"KiB/s", "MiB/s", "GiB/s", "TiB/s"}
+    value, i := scaleUnits(bytesPerSec/unit, len(units))
+    return fmt.Sprintf("%.1f%s", value, units[i])
+}
+
+// scaleUnits divides value by 1024 until it prints below 1024.0 with one
+// decimal, so that e.g. 1048575 bytes become 1.0MiB rather than 1024.0KiB.
+// It returns the scaled value and the index of its unit, at most units-1.
+func scaleUnits(value float64, units int) (float64, int) {
+    i := 0
+    for math.Round(value*10)/10 >= 1024 && i < units-1 {
+        value /= 1024
+        i++
+    }
+    return value, i
+}
+
+// FormatPIDs renders the process count, with the limit when one is set.
+func FormatPIDs(current, limit uint64) string {
+    if limit == 0 || limit == math.MaxUint64 {
+        return fmt.Sprintf("%d", current)
+    }
+    return fmt.Sprintf("%d/%d", current, limit)
+}
@@ -0,0 +1,64 @@
+package docker
+
+import (
+    "math"
+    "testing"
+)
+
+func TestFormatBytes(t *testing.T) {
+    tests := []struct {
+        in   uint64
+        want string
+    }{
+        {0, "0B"},
+        {1023, "1023B"},
+        {1024, "1.0KiB"},
+        {1536, "1.5KiB"},
+        {1048575, "1.0MiB"},
+        {1048576, "1.0MiB"},
+        {5 << 30, "5.0GiB"},
+        {1<<40 - 1, "1.0TiB"},
+    }
+    for _, tt := range tests {
+        if got := FormatBytes(tt.in); got != tt.want {
+            t.Errorf("FormatBytes(%d) = %s, want %s", tt.in, got, tt.want)
+        }
+    }
+}
+
+func TestFormatPIDs(t *testing.T) {
+    tests := []struct {
+        current, limit uint64
+        want           string
+    }{
+        {3, 0, "3"},
+        {3, math.MaxUint64, "3"},
+        {3, 100, "3/100"},
+    }
+    for _, tt := range tests {
+        if got := FormatPIDs(tt.current, tt.limit); got != tt.want {
+            t.Errorf("FormatPIDs(%d, %d) = %s, want %s", tt.current, tt.limit, got, tt.want)
+        }
+    }
+}
+
+func TestFormatRate(t *testing.T) {
+    tests := []struct {
+        in   float64
+        want string
+    }{
+        {0, "0B/s"},
+        {512, "512B/s"},
+        {1023.4, "1023B/s"},
+        {1023.6, "1.0KiB/s"},
+        {2048, "2.0KiB/s"},
+        {1048575, "1.0MiB/s"},
+        {1536 << 10, "1.5MiB/s"},
+        {3 << 30, "3.0GiB/s"},
+    }
+    for _, tt := range tests {
+        if got := FormatRate(tt.in); got != tt.want {
+            t.Errorf("FormatRate(%v) = %s, want %s", tt.in, got, tt.want)
+        }
+    }
+}
@@ -0,0 +1,146 @@
+package ui
+
+import (
+    "fmt"
+    "strings"
+
+    "docker-manager/internal/docker"
+
+    "github.com/charmbracelet/bubbles/key"
+    "github.com/charmbracelet/bubbles/textinput"
+    tea "github.com/charmbracelet/bubbletea"
+    "github.com/charmbracelet/lipgloss"
+)
+
+// killSignals are offered by the kill picker, most useful first.
+var killSignals = []struct {
+    name string
+    desc string
+}{
+    {"SIGTERM", "ask the process to terminate"},
+    {"SIGKILL", "kill immediately"},
+    {"SIGINT", "interrupt, like Ctrl+C"},
+    {"SIGHUP", "hang up, often reloads configuration"},
+    {"SIGQUIT", "quit, often with a core dump"},
+    {"SIGUSR1", "user-defined signal 1"},
+    {"SIGUSR2", "user-defined signal 2"},
+}
+
+// killPicker is the signal choice shown in SignalView.
+type killPicker struct {
+    targets []docker.ContainerInfo
+    cursor  int
+}
+
+// renamePrompt is the inline rename input shown under the table.
+type renamePrompt struct {
+    active bool
+    target docker.ContainerInfo
+    input  textinput.Model
+    err    error
+}
+
+func (m *Model) openKillPicker(targets []docker.ContainerInfo) {
+    m.kill = killPicker{targets: targets}
+    m.currentView = SignalView
+}
+
+func (m *Model) updateSignalView(msg tea.KeyMsg) (Model, tea.Cmd) {
+    switch {
+    case key.Matches(msg, Keys.Up):
+        m.kill.cursor = (m.kill.cursor - 1 + len(killSignals)) % len(killSignals)
+
+    case key.Matches(msg, Keys.Down):
+        m.kill.cursor = (m.kill.cursor + 1) % len(killSignals)
+
+    case key.Matches(msg, Keys.Enter):
+        m.currentView = ContainersView
+        signal := killSignals[m.kill.cursor].name
+        runtime := m.runtime
+        return *m, m.runBulk("kill", m.kill.targets, func(id string) error {
+            return runtime.KillContainer(id, signal)
+        })
+
+    case key.Matches(msg, Keys.Back), key.Matches(msg, Keys.Cancel):
+        m.currentView = ContainersView
+    }
+    return *m, nil
+}
+
+func (m Model) signalView() string {
+    var body strings.Builder
+
+    if len(m.kill.targets) == 1 {
+        body.WriteString(fmt.Sprintf("Send a signal to %s\n\n", m.kill.targets[0].Name))
+    } else {
+        body.WriteString(fmt.Sprintf("Send a signal to %d containers\n\n", len(m.kill.targets)))
+    }
+    for i, sig := range killSignals {
+        line := fmt.Sprintf("%-8s %s", sig.name, sig.desc)
+        if i == m.kill.cursor {
+            body.WriteString(SelectedStyle.Render("> " + line))
+        } else {
+            body.WriteString("  " + line)
+        }
+        body.WriteString("\n")
+    }
+
+    var b strings.Builder
+    b.WriteString(TitleStyle.Render("⚡ Kill"))
+    b.WriteString("\n\n")
+    b.WriteString(ConfirmStyle.Render(strings.TrimRight(body.String(), "\n")))
+    b.WriteString("\n\n")
+    b.WriteString(HelpStyle.Render("↑/↓: Choose • Enter: Send • esc: Cancel"))
+
+    if m.width > 0 && m.height > 0 {
+        return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, b.String())
+    }
+    return b.String()
+}
+
+func (m *Model) openRename(c docker.ContainerInfo) tea.Cmd {
+    ti := textinput.New()
+    ti.Prompt = "Rename " + c.Name + " to: "
+    ti.CharLimit = 128
+    ti.Width = 40
+    ti.SetValue(c.Name)
+    ti.CursorEnd()
+
+    m.rename = renamePrompt{active: true, target: c, input: ti}
+    return m.rename.input.Focus()
+}
+
+func (m *Model) updateRenameInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+    switch {
+    case key.Matches(msg, Keys.Enter):
+        name := strings.TrimSpace(m.rename.input.Value())
+        if name == "" {
+            m.rename.err = fmt.Errorf("the new name can't be empty")
+            return *m, nil
+        }
+        m.rename.active = false
+        if name == m.rename.target.Name {
+            return *m, nil
+        }
+        runtime := m.runtime
+        return *m, m.runBulk("rename", []docker.ContainerInfo{m.rename.target}, func(id string) error {
+            return runtime.RenameContainer(id, name)
+        })
+
+    case key.Matches(msg, Keys.Back):
+        m.rename.active = false
+        return *m, nil
+    }
+
+    var cmd tea.Cmd
+    m.rename.input, cmd = m.rename.input.Update(msg)
+    return *m, cmd
+}
+
+func (m Model) renameView() string {
+    view := m.rename.input.View()
+    if m.rename.err != nil {
+        view += "\n" + ContainerStoppedStyle.Render(m.rename.err.Error())
+    }
+    return view
+}
@@ -0,0 +1,298 @@
+package ui
+
+import (
+    "fmt"
+    "strings"
+    "sync"
+    "time"
+
+    "docker-manager/internal/docker"
+
+    tea "github.com/charmbracelet/bubbletea"
+)
+
+// bulkWorkers bounds how many containers an action runs against at once.
+const bulkWorkers = 8
+
+type bulkResult struct {
+    action string
+    id     string
+    name   string
+    err    error
+}
+
+// bulkState summarises the latest action, which may run against several
+// containers.
+type bulkState struct {
+    // gen identifies the latest action; older ones still finish but no
+    // longer count towards the summary
+    gen      int
+    action   string
+    total    int
+    finished int
+    results  map[string]error
+    failed   []bulkResult
+    done     bool
+}
+
+// inFlight is an action still running against one container. Rows show it
+// until the result arrives, whichever action is the latest.
+type inFlight struct {
+    gen     int
+    action  string
+    started time.Time
+    // limit is how long the action may take, if known
+    limit time.Duration
+}
+
+type bulkResultMsg struct {
+    gen    int
+    ch     <-chan bulkResult
+    result bulkResult
+}
+
+type bulkDoneMsg struct {
+    gen int
+}
+
+// actionTargets returns the marked containers, or the selected one when
+// nothing is marked.
+func (m *Model) actionTargets() []docker.ContainerInfo {
+    if marked := m.markedContainers(); len(marked) > 0 {
+        return marked
+    }
+    if c, ok := m.selectedContainer(); ok {
+        return []docker.ContainerInfo{c}
+    }
+    return nil
+}
+
+// runBulk runs op against every target concurrently. Each result is
+// reported as it arrives so the table can show progress.
+func (m *Model) runBulk(action string, targets []docker.ContainerInfo, op func(id string) error) tea.Cmd {
+    return m.runBulkWithin(action, targets, 0, op)
+}
+
+// runBulkWithin is runBulk for actions known to take up to limit, which
+// the rows show next to the elapsed time.
+func (m *Model) runBulkWithin(action string, targets []docker.ContainerInfo, limit time.Duration, op func(id string) error) tea.Cmd {
+    if len(targets) == 0 {
+        return nil
+    }
+
+    ch := make(chan bulkResult, len(targets))
+    m.bulk = bulkState{
+        gen:     m.bulk.gen + 1,
+        action:  action,
+        total:   len(targets),
+        results: make(map[string]error, len(targets)),
+    }
+    now := time.Now()
+    for _, c := range targets {
+        m.inFlight[c.ID] = inFlight{gen: m.bulk.gen, action: action, started: now, limit: limit}
+    }
+    m.updateTableRows()
+
+    jobs := make(chan docker.ContainerInfo)
+    var wg sync.WaitGroup
+    workers := min(bulkWorkers, len(targets))
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for c := range jobs {
+                ch <- bulkResult{action: action, id: c.ID, name: c.Name, err: op(c.ID)}
+            }
+        }()
+    }
+    go func() {
+        for _, c := range targets {
+            jobs <- c
+        }
+        close(jobs)
+        wg.Wait()
+        close(ch)
+    }()
+
+    return waitForBulk(m.bulk.gen, ch)
+}
+
+func waitForBulk(gen int, ch <-chan bulkResult) tea.Cmd {
+    return func() tea.Msg {
+        result, ok := <-ch
+        if !ok {
+            return bulkDoneMsg{gen: gen}
+        }
+        return bulkResultMsg{gen: gen, ch: ch, result: result}
+    }
+}
+
+func (m *Model) updateBulk(msg tea.Msg) tea.Cmd {
+    switch msg := msg.(type) {
+    case bulkResultMsg:
+        r := msg.result
+        // A newer action on the same container owns its row now
+        if f, ok := m.inFlight[r.id]; ok && f.gen == msg.gen {
+            delete(m.inFlight, r.id)
+        }
+        if msg.gen == m.bulk.gen {
+            m.bulk.finished++
+            m.bulk.results[r.id] = r.err
+            if r.err != nil {
+                m.bulk.failed = append(m.bulk.failed, r)
+            }
+        }
+        m.updateTableRows()
+
+        cmd := waitForBulk(msg.gen, msg.ch)
+        if docker.IsConnectionError(r.err) {
+            return tea.Batch(cmd, m.disconnect(r.err))
+        }
+        if r.err != nil {
+            return tea.Batch(cmd, m.notify(notifyError, "%s %s failed: %v", r.action, r.name, r.err))
+        }
+        return cmd
+
+    case bulkDoneMsg:
+        cmds := []tea.Cmd{m.refreshContainers()}
+        if msg.gen == m.bulk.gen {
+            m.bulk.done = true
+            if ok := m.bulk.finished - len(m.bulk.failed); ok > 0 {
+                cmds = append(cmds, m.notify(notifySuccess, "%s: %s", m.bulk.action, m.bulkSucceeded()))
+            }
+        }
+        return tea.Batch(cmds...)
+    }
+    return nil
+}
+
+// bulkSucceeded names the containers the latest action succeeded on, or
+// counts them when there are many.
+func (m *Model) bulkSucceeded() string {
+    var names []string
+    for _, c := range m.containers {
+        if err, ok := m.bulk.results[c.ID]; ok && err == nil {
+            names = append(names, c.Name)
+        }
+    }
+    ok := m.bulk.finished - len(m.bulk.failed)
+    if len(names) != ok || ok > 3 {
+        return fmt.Sprintf("%d containers", ok)
+    }
+    return strings.Join(names, ", ")
+}
+
+// actionProgress names an action while it runs.
+var actionProgress = map[string]string{
+    "start":   "Starting",
+    "stop":    "Stopping",
+    "restart": "Restarting",
+    "pause":   "Pausing",
+    "unpause": "Unpausing",
+    "kill":    "Killing",
+    "rename":  "Renaming",
+    "remove":  "Removing",
+}
+
+// inFlightStatus replaces the status of a row with a running action, e.g.
+// "Stopping… 4s/10s".
+func (m *Model) inFlightStatus(id string) (string, bool) {
+    f, ok := m.inFlight[id]
+    if !ok {
+        return "", false
+    }
+    status := actionProgress[f.action] + "… " + time.Since(f.started).Truncate(time.Second).String()
+    if f.limit > 0 {
+        status += "/" + f.limit.String()
+    }
+    return BulkPendingStyle.Render(status), true
+}
+
+// bulkMarker shows a container's progress in the latest action.
+func (m *Model) bulkMarker(id string) string {
+    if _, ok := m.inFlight[id]; ok {
+        return BulkPendingStyle.Render("…") + " "
+    }
+    err, ok := m.bulk.results[id]
+    switch {
+    case !ok:
+        return ""
+    case err != nil:
+        return BulkFailedStyle.Render("✗") + " "
+    default:
+        return BulkDoneStyle.Render("✓") + " "
+    }
+}
+
+// bulkStatus shows the progress of a running action in the status bar.
+// Results are reported as notifications.
+func (m Model) bulkStatus() string {
+    b := m.bulk
+    if b.total == 0 || b.done {
+        return ""
+    }
+    return fmt.Sprintf("%s %d/%d...", b.action, b.finished, b.total)
+}
+
+func (m *Model) startContainers() tea.Cmd {
+    return m.runBulk("start", m.actionTargets(), m.runtime.StartContainer)
+}
+
+func (m *Model) stopContainers() tea.Cmd {
+    runtime, opts := m.runtime, m.stopOpts
+    return m.runBulkWithin("stop", m.actionTargets(), opts.Wait(), func(id string) error {
+        return runtime.StopContainer(id, opts)
+    })
+}
+
+func (m *Model) restartContainers() tea.Cmd {
+    runtime, opts := m.runtime, m.stopOpts
+    return m.runBulkWithin("restart", m.actionTargets(), opts.Wait(), func(id string) error {
+        return runtime.RestartContainer(id, opts)
+    })
+}
+
+func (m *Model) pauseContainers() tea.Cmd {
+    return m.runBulk("pause", m.actionTargets(), m.runtime.PauseContainer)
+}
+
+func (m *Model) unpauseContainers() tea.Cmd {
+    return m.runBulk("unpause", m.actionTargets(), m.runtime.UnpauseContainer)
+}
+
+func (m *Model) removeContainers(targets []docker.ContainerInfo, opts docker.RemoveOptions) tea.Cmd {
+    runtime := m.runtime
+    return m.runBulk("remove", targets, func(id string) error {
+        return runtime.RemoveContainer(id, opts)
+    })
+}
+
+// markAll marks every listed container, or clears the marks if they are
+// all marked already.
+func (m *Model) markAll() {
+    all := len(m.containers) > 0
+    for _, c := range m.containers {
+        if !m.marked[c.ID] {
+            all = false
+            break
+        }
+    }
+
+    m.marked = make(map[string]bool)
+    if !all {
+        for _, c := range m.containers {
+            m.marked[c.ID] = true
+        }
+    }
+    m.updateTableRows()
+}
+
+// markMatching adds the listed containers matching filters to the marks.
+func (m *Model) markMatching(filters []docker.Filter) {
+    for _, c := range m.containers {
+        if docker.MatchFilters(c, filters) {
+            m.marked[c.ID] = true
+        }
+    }
+    m.updateTableRows()
+}
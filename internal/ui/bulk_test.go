@@ -0,0 +1,187 @@
+package ui
+
+import (
+    "sort"
+    "strings"
+    "testing"
+
+    "docker-manager/internal/docker"
+
+    tea "github.com/charmbracelet/bubbletea"
+)
+
+// runBulkAction feeds the results of an action started by cmd back into m
+// until the action is done. Commands returned along the way are not run.
+func runBulkAction(t *testing.T, m Model, cmd tea.Cmd) Model {
+    t.Helper()
+    if cmd == nil {
+        t.Fatal("no command returned")
+    }
+    msg := cmd()
+    for {
+        updated, _ := m.Update(msg)
+        m = updated.(Model)
+        result, ok := msg.(bulkResultMsg)
+        if !ok {
+            return m
+        }
+        msg = waitForBulk(result.gen, result.ch)()
+    }
+}
+
+// callsTo returns the calls of method recorded by fake, sorted since bulk
+// actions run concurrently.
+func callsTo(fake *docker.FakeRuntime, method string) string {
+    var calls []string
+    for _, call := range fake.Calls() {
+        if strings.HasPrefix(call, method+"(") {
+            calls = append(calls, call)
+        }
+    }
+    sort.Strings(calls)
+    return strings.Join(calls, " ")
+}
+
+// historyTexts returns the text of every notification, sorted since bulk
+// results arrive in any order.
+func historyTexts(m Model) string {
+    var texts []string
+    for _, n := range m.history {
+        texts = append(texts, n.text)
+    }
+    sort.Strings(texts)
+    return strings.Join(texts, "; ")
+}
+
+// typeFilter opens the prompt with key, enters input and returns the
+// model along with the command the prompt returned.
+func typeFilter(t *testing.T, m Model, key, input string) (Model, tea.Cmd) {
+    t.Helper()
+    m, _ = press(t, m, key)
+    m, _ = press(t, m, input)
+    updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+    return updated.(Model), cmd
+}
+
+func TestStopActsOnSelectedContainerWithoutMarks(t *testing.T) {
+    m, fake := newTestModel(t)
+
+    m, cmd := press(t, m, "t")
+    runBulkAction(t, m, cmd)
+
+    if got := callsTo(fake, "StopContainer"); got != "StopContainer(aaaaaaaaaaaa)" {
+        t.Errorf("calls = %s, want only the selected web", got)
+    }
+}
+
+func TestStopActsOnMarkedContainers(t *testing.T) {
+    m, fake := newTestModel(t)
+
+    m, _ = press(t, m, "a")
+    if len(m.marked) != 2 {
+        t.Fatalf("marked = %v, want both containers", m.marked)
+    }
+
+    m, cmd := press(t, m, "t")
+    if len(m.inFlight) != 2 || m.bulkStatus() != "stop 0/2..." {
+        t.Errorf("in flight = %v, status = %q; want both containers stopping", m.inFlight, m.bulkStatus())
+    }
+    m = runBulkAction(t, m, cmd)
+
+    if got := callsTo(fake, "StopContainer"); got != "StopContainer(aaaaaaaaaaaa) StopContainer(bbbbbbbbbbbb)" {
+        t.Errorf("calls = %s", got)
+    }
+    if !m.bulk.done || m.bulk.finished != 2 || len(m.bulk.failed) != 0 || len(m.inFlight) != 0 {
+        t.Errorf("bulk = %+v; want done without failures", m.bulk)
+    }
+    if got := historyTexts(m); got != "stop: web, db" {
+        t.Errorf("notifications = %q", got)
+    }
+
+    // Pressing it again clears the marks
+    m, _ = press(t, m, "a")
+    if len(m.marked) != 0 {
+        t.Errorf("marked = %v, want none", m.marked)
+    }
+}
+
+func TestBulkActionReportsFailures(t *testing.T) {
+    m, fake := newTestModel(t)
+    fake.Fail("RestartContainer", errTest)
+
+    m, _ = press(t, m, "a")
+    m, cmd := press(t, m, "r")
+    m = runBulkAction(t, m, cmd)
+
+    if len(m.bulk.failed) != 2 || m.bulkStatus() != "" {
+        t.Errorf("status = %q, failed = %+v", m.bulkStatus(), m.bulk.failed)
+    }
+    if got := historyTexts(m); got != "restart db failed: daemon gone; restart web failed: daemon gone" {
+        t.Errorf("notifications = %q, want one failure per container", got)
+    }
+}
+
+func TestMarkMatchingUnderFilter(t *testing.T) {
+    m, fake := newTestModel(t)
+    fake.Containers[0].Labels = map[string]string{"tier": "frontend"}
+    fake.Containers[1].Labels = map[string]string{"tier": "backend"}
+    fake.Containers = append(fake.Containers, docker.ContainerInfo{
+        ID: "cccccccccccc", Name: "job", State: "exited", Status: "Exited (0) 1 hour ago",
+        Labels: map[string]string{"tier": "backend"},
+    })
+
+    m, refresh := typeFilter(t, m, "f", "status=running")
+    updated, _ := m.Update(refresh())
+    m = updated.(Model)
+    if len(m.containers) != 2 {
+        t.Fatalf("containers = %+v, want the two running ones", m.containers)
+    }
+
+    m, cmd := typeFilter(t, m, "m", "label=tier=backend")
+    if cmd != nil {
+        t.Error("marking should not reload the list")
+    }
+
+    // job matches too, but is hidden by the list filter
+    if len(m.marked) != 1 || !m.marked["bbbbbbbbbbbb"] {
+        t.Errorf("marked = %v, want only db", m.marked)
+    }
+    if m.filter != "status=running" || len(m.filters) != 1 || m.textinput.Value() != "status=running" {
+        t.Errorf("filter = %q %v, prompt = %q; want the list filter kept", m.filter, m.filters, m.textinput.Value())
+    }
+}
+
+func TestStaleBulkResultsAreIgnored(t *testing.T) {
+    m, _ := newTestModel(t)
+
+    m, stop := press(t, m, "t")
+    stale := stop().(bulkResultMsg)
+    // A refresh lands and a second action starts before the first one's
+    // result arrives
+    updated, _ := m.Update(m.refreshContainers()())
+    m = updated.(Model)
+    m, _ = press(t, m, "s")
+
+    updated, _ = m.Update(stale)
+    m = updated.(Model)
+    updated, _ = m.Update(bulkDoneMsg{gen: stale.gen})
+    m = updated.(Model)
+
+    if m.bulk.action != "start" || m.bulk.done || m.bulk.finished != 0 || len(m.bulk.results) != 0 {
+        t.Errorf("bulk = %+v; want the start still running", m.bulk)
+    }
+    if f, ok := m.inFlight["aaaaaaaaaaaa"]; !ok || f.gen != m.bulk.gen || f.action != "start" {
+        t.Errorf("in flight = %+v; want web starting", m.inFlight)
+    }
+}
+
+func TestDestroyEventDropsMark(t *testing.T) {
+    m, _ := newTestModel(t)
+    m, _ = press(t, m, "a")
+
+    m.applyEvent(docker.ContainerEvent{Action: docker.EventDestroy, ID: "aaaaaaaaaaaa"})
+
+    if len(m.containers) != 1 || len(m.marked) != 1 || !m.marked["bbbbbbbbbbbb"] {
+        t.Errorf("containers = %d, marked = %v; want only db left", len(m.containers), m.marked)
+    }
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+    "fmt"
+    "strings"
+
+    "docker-manager/internal/docker"
+
+    "github.com/charmbracelet/bubbles/key"
+    tea "github.com/charmbracelet/bubbletea"
+    "github.com/charmbracelet/lipgloss"
+)
+
+// removeConfirm is the pending removal shown in ConfirmView.
+type removeConfirm struct {
+    targets []docker.ContainerInfo
+    opts    docker.RemoveOptions
+}
+
+// confirmRemove asks before removing targets.
+func (m *Model) confirmRemove(targets ...docker.ContainerInfo) {
+    m.confirm = removeConfirm{targets: targets}
+    m.currentView = ConfirmView
+}
+
+func (m *Model) updateConfirmView(msg tea.KeyMsg) (Model, tea.Cmd) {
+    switch {
+    case key.Matches(msg, Keys.Confirm):
+        m.currentView = ContainersView
+        return *m, m.removeContainers(m.confirm.targets, m.confirm.opts)
+
+    case key.Matches(msg, Keys.Cancel), key.Matches(msg, Keys.Back):
+        m.currentView = ContainersView
+        return *m, nil
+
+    case key.Matches(msg, Keys.ToggleForce):
+        m.confirm.opts.Force = !m.confirm.opts.Force
+
+    case key.Matches(msg, Keys.ToggleVolumes):
+        m.confirm.opts.RemoveVolumes = !m.confirm.opts.RemoveVolumes
+    }
+    return *m, nil
+}
+
+func (m Model) confirmView() string {
+    var body strings.Builder
+
+    c := m.confirm
+    if len(c.targets) == 1 {
+        body.WriteString(fmt.Sprintf("Remove container %s?\n\n", c.targets[0].Name))
+    } else {
+        body.WriteString(fmt.Sprintf("Remove %d containers?\n\n", len(c.targets)))
+    }
+
+    running := 0
+    for _, t := range c.targets {
+        body.WriteString(fmt.Sprintf("  %-30s %s\n", t.Name, containerStatusStyle(t.Status).Render(t.Status)))
+        if t.State == "running" {
+            running++
+        }
+    }
+    body.WriteString("\n")
+
+    body.WriteString(checkbox(c.opts.Force) + " f: Force (kill running containers first)\n")
+    body.WriteString(checkbox(c.opts.RemoveVolumes) + " v: Remove anonymous volumes\n")
+
+    if running > 0 && !c.opts.Force {
+        body.WriteString("\n")
+        body.WriteString(ContainerPausedStyle.Render(fmt.Sprintf("%d running: removal fails unless forced", running)))
+        body.WriteString("\n")
+    }
+
+    var b strings.Builder
+    b.WriteString(TitleStyle.Render("🗑  Confirm removal"))
+    b.WriteString("\n\n")
+    b.WriteString(ConfirmStyle.Render(strings.TrimRight(body.String(), "\n")))
+    b.WriteString("\n\n")
+    b.WriteString(HelpStyle.Render("y: Remove • n/esc: Cancel • f: Toggle force • v: Toggle volumes"))
+
+    // Centre the dialog when the terminal size is known
+    if m.width > 0 && m.height > 0 {
+        return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, b.String())
+    }
+    return b.String()
+}
+
+func checkbox(on bool) string {
+    if on {
+        return "[x]"
+    }
+    return "[ ]"
+}
@@ -0,0 +1,126 @@
+package ui
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "docker-manager/internal/docker"
+
+    tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+    reconnectMinBackoff = time.Second
+    reconnectMaxBackoff = 30 * time.Second
+)
+
+// connState tracks whether the daemon is reachable. While it isn't, the
+// last known containers stay on screen, marked stale.
+type connState struct {
+    server       docker.ServerInfo
+    disconnected bool
+    err          error
+    attempt      int
+    backoff      time.Duration
+    retryAt      time.Time
+    // lastUpdate is when the container list was last loaded
+    lastUpdate time.Time
+}
+
+type pingMsg struct {
+    server docker.ServerInfo
+    err    error
+}
+
+// reconnectMsg fires when it is time for the next attempt.
+type reconnectMsg struct {
+    attempt int
+}
+
+func pingCmd(ctx context.Context, runtime docker.ContainerRuntime) tea.Cmd {
+    return func() tea.Msg {
+        server, err := runtime.Ping(ctx)
+        return pingMsg{server: server, err: err}
+    }
+}
+
+// disconnect switches to the disconnected state, if not already in it,
+// and schedules the first reconnection attempt.
+func (m *Model) disconnect(err error) tea.Cmd {
+    m.conn.err = err
+    if m.conn.disconnected {
+        return nil
+    }
+    m.conn.disconnected = true
+    m.conn.attempt = 0
+    m.conn.backoff = reconnectMinBackoff
+    return tea.Batch(
+        m.notify(notifyError, "Lost connection to Docker: %v", err),
+        m.scheduleReconnect(),
+    )
+}
+
+func (m *Model) scheduleReconnect() tea.Cmd {
+    m.conn.attempt++
+    m.conn.retryAt = time.Now().Add(m.conn.backoff)
+    attempt := m.conn.attempt
+    return tea.Tick(m.conn.backoff, func(time.Time) tea.Msg {
+        return reconnectMsg{attempt: attempt}
+    })
+}
+
+// retryNow skips the rest of the current backoff.
+func (m *Model) retryNow() tea.Cmd {
+    m.conn.attempt++
+    return pingCmd(m.ctx, m.runtime)
+}
+
+func (m *Model) updateConnection(msg tea.Msg) tea.Cmd {
+    switch msg := msg.(type) {
+    case reconnectMsg:
+        // A manual retry may have superseded this attempt
+        if !m.conn.disconnected || msg.attempt != m.conn.attempt {
+            return nil
+        }
+        return pingCmd(m.ctx, m.runtime)
+
+    case pingMsg:
+        if msg.err != nil {
+            if !m.conn.disconnected {
+                if docker.IsConnectionError(msg.err) {
+                    return m.disconnect(msg.err)
+                }
+                return nil
+            }
+            m.conn.err = msg.err
+            m.conn.backoff = min(m.conn.backoff*2, reconnectMaxBackoff)
+            return m.scheduleReconnect()
+        }
+
+        m.conn.server = msg.server
+        if !m.conn.disconnected {
+            return nil
+        }
+        m.conn.disconnected = false
+        m.conn.err = nil
+        return tea.Batch(
+            m.notify(notifySuccess, "Reconnected to %s", msg.server),
+            m.refreshContainers(),
+        )
+    }
+    return nil
+}
+
+func (m Model) connectionBanner() string {
+    if !m.conn.disconnected {
+        return ""
+    }
+    wait := time.Until(m.conn.retryAt).Round(time.Second)
+    retry := "retrying now"
+    if wait > 0 {
+        retry = fmt.Sprintf("retrying in %s", wait)
+    }
+    return DisconnectedStyle.Render(fmt.Sprintf("⚠ Disconnected from Docker — %s (attempt %d, F5 to retry now): %v",
+        retry, m.conn.attempt, m.conn.err))
+}
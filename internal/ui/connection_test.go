@@ -0,0 +1,99 @@
+package ui
+
+import (
+    "strings"
+    "testing"
+    "time"
+
+    "docker-manager/internal/docker"
+
+    tea "github.com/charmbracelet/bubbletea"
+)
+
+// disconnectTestModel returns a model that lost the daemon while loading
+// the container list, with the daemon still down.
+func disconnectTestModel(t *testing.T) (Model, *docker.FakeRuntime) {
+    t.Helper()
+    m, fake := newTestModel(t)
+    fake.Fail("ListContainers", docker.ErrDaemonUnreachable)
+    fake.Fail("Ping", docker.ErrDaemonUnreachable)
+
+    updated, _ := m.Update(m.refreshContainers()())
+    return updated.(Model), fake
+}
+
+// reconnect delivers the pending reconnection attempt and the ping it
+// makes.
+func reconnect(t *testing.T, m Model) Model {
+    t.Helper()
+    ping := m.updateConnection(reconnectMsg{attempt: m.conn.attempt})
+    if ping == nil {
+        t.Fatal("no ping for the pending attempt")
+    }
+    updated, _ := m.Update(ping())
+    return updated.(Model)
+}
+
+func TestListFailureKeepsStaleRows(t *testing.T) {
+    m, _ := disconnectTestModel(t)
+
+    if !m.conn.disconnected || m.conn.err != docker.ErrDaemonUnreachable {
+        t.Fatalf("conn = %+v, want disconnected", m.conn)
+    }
+    if len(m.containers) != 2 || len(m.table.Rows()) != 2 {
+        t.Errorf("containers = %+v, want the last known two kept", m.containers)
+    }
+    view := m.View()
+    if !strings.Contains(view, "Disconnected from Docker") || !strings.Contains(view, "STALE") {
+        t.Errorf("view has no stale banner:\n%s", view)
+    }
+}
+
+func TestReconnectBackoffDoublesUpToCap(t *testing.T) {
+    m, _ := disconnectTestModel(t)
+    if m.conn.attempt != 1 || m.conn.backoff != reconnectMinBackoff {
+        t.Fatalf("conn = %+v, want the first attempt after %s", m.conn, reconnectMinBackoff)
+    }
+
+    for _, want := range []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 30 * time.Second, 30 * time.Second} {
+        attempt := m.conn.attempt
+        m = reconnect(t, m)
+        if m.conn.backoff != want || m.conn.attempt != attempt+1 || !m.conn.disconnected {
+            t.Fatalf("after attempt %d: conn = %+v, want the next after %s", attempt, m.conn, want)
+        }
+    }
+
+    // A manual retry supersedes the scheduled attempt
+    stale := m.conn.attempt
+    updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyF5})
+    m = updated.(Model)
+    if m.conn.attempt != stale+1 {
+        t.Errorf("attempt = %d after F5, want %d", m.conn.attempt, stale+1)
+    }
+    if cmd := m.updateConnection(reconnectMsg{attempt: stale}); cmd != nil {
+        t.Error("superseded attempt still pinged")
+    }
+}
+
+func TestReconnectClearsBanner(t *testing.T) {
+    m, fake := disconnectTestModel(t)
+    m = reconnect(t, m)
+
+    fake.Fail("Ping", nil)
+    fake.Fail("ListContainers", nil)
+    fake.Containers[1].State, fake.Containers[1].Status = "exited", "Exited (0) 1 second ago"
+    m = reconnect(t, m)
+    if m.conn.disconnected || m.conn.err != nil || m.connectionBanner() != "" {
+        t.Fatalf("conn = %+v, want connected again", m.conn)
+    }
+    if got := m.history[len(m.history)-1].text; got != "Reconnected to Docker fake (API 1.43, linux/amd64)" {
+        t.Errorf("last notification = %q", got)
+    }
+
+    // The list is reloaded in place of the stale one
+    updated, _ := m.Update(m.refreshContainers()())
+    m = updated.(Model)
+    if m.containers[1].State != "exited" || strings.Contains(m.View(), "STALE") {
+        t.Errorf("containers = %+v, want the reloaded list", m.containers)
+    }
+}
@@ -0,0 +1,381 @@
+package ui
+
+import (
+    "fmt"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+
+    "docker-manager/internal/docker"
+
+    "github.com/charmbracelet/bubbles/key"
+    tea "github.com/charmbracelet/bubbletea"
+)
+
+var detailTabs = []string{"Config", "Mounts", "Network", "Ports", "Resources", "State", "JSON"}
+
+// detailState is the inspect view of one container.
+type detailState struct {
+    container docker.ContainerInfo
+    details   *docker.ContainerDetails
+    err       error
+    loading   bool
+    tab       int
+}
+
+type detailMsg struct {
+    id      string
+    details *docker.ContainerDetails
+    err     error
+}
+
+func (m *Model) openDetail(c docker.ContainerInfo) tea.Cmd {
+    m.detail = detailState{container: c, tab: m.detail.tab}
+    m.currentView = DetailView
+    return m.loadDetail()
+}
+
+func (m *Model) loadDetail() tea.Cmd {
+    m.detail.loading = true
+    runtime, id := m.runtime, m.detail.container.ID
+    return func() tea.Msg {
+        details, err := runtime.InspectContainer(id)
+        return detailMsg{id: id, details: details, err: err}
+    }
+}
+
+func (m *Model) updateDetail(msg detailMsg) {
+    // The view may have moved on to another container
+    if msg.id != m.detail.container.ID {
+        return
+    }
+    m.detail.loading = false
+    m.detail.details = msg.details
+    m.detail.err = msg.err
+    m.refreshDetailView()
+}
+
+func (m *Model) refreshDetailView() {
+    m.detailPort.SetContent(m.renderDetailTab())
+    m.detailPort.GotoTop()
+}
+
+func (m *Model) updateDetailView(msg tea.KeyMsg) (Model, tea.Cmd) {
+    switch {
+    case key.Matches(msg, Keys.Back):
+        m.currentView = ContainersView
+        return *m, nil
+
+    case key.Matches(msg, Keys.Quit):
+        return *m, m.quit()
+
+    case key.Matches(msg, Keys.NextTab):
+        m.detail.tab = (m.detail.tab + 1) % len(detailTabs)
+        m.refreshDetailView()
+        return *m, nil
+
+    case key.Matches(msg, Keys.PrevTab):
+        m.detail.tab = (m.detail.tab - 1 + len(detailTabs)) % len(detailTabs)
+        m.refreshDetailView()
+        return *m, nil
+
+    case key.Matches(msg, Keys.Refresh):
+        return *m, m.loadDetail()
+    }
+
+    // Number keys jump straight to a tab
+    if n, err := strconv.Atoi(msg.String()); err == nil && n >= 1 && n <= len(detailTabs) {
+        m.detail.tab = n - 1
+        m.refreshDetailView()
+        return *m, nil
+    }
+
+    var cmd tea.Cmd
+    m.detailPort, cmd = m.detailPort.Update(msg)
+    return *m, cmd
+}
+
+func (m Model) detailView() string {
+    var b strings.Builder
+
+    title := "🔎 " + m.detail.container.Name
+    if m.detail.loading {
+        title += " (loading...)"
+    }
+    b.WriteString(TitleStyle.Render(title))
+    b.WriteString("\n\n")
+
+    tabs := make([]string, len(detailTabs))
+    for i, name := range detailTabs {
+        label := fmt.Sprintf("%d %s", i+1, name)
+        if i == m.detail.tab {
+            tabs[i] = ActiveTabStyle.Render(label)
+        } else {
+            tabs[i] = TabStyle.Render(label)
+        }
+    }
+    b.WriteString(strings.Join(tabs, " "))
+    b.WriteString("\n\n")
+
+    b.WriteString(m.detailPort.View())
+    b.WriteString("\n\n")
+
+    b.WriteString(HelpStyle.Render("tab/shift+tab or 1-7: Switch tab • ↑/↓: Scroll • F5: Reload • esc: Back • q: Quit"))
+    return b.String()
+}
+
+func (m Model) renderDetailTab() string {
+    if m.detail.err != nil {
+        return ContainerStoppedStyle.Render("Inspect failed: " + m.detail.err.Error())
+    }
+    d := m.detail.details
+    if d == nil {
+        return ""
+    }
+
+    switch detailTabs[m.detail.tab] {
+    case "Config":
+        return renderConfig(d)
+    case "Mounts":
+        return renderMounts(d.Mounts)
+    case "Network":
+        return renderNetworks(d.Networks)
+    case "Ports":
+        return renderPorts(d.Ports)
+    case "Resources":
+        return renderResources(d)
+    case "State":
+        return renderState(d.State)
+    case "JSON":
+        return string(d.Raw)
+    }
+    return ""
+}
+
+// fields renders label/value pairs with the values aligned.
+func fields(pairs ...string) string {
+    width := 0
+    for i := 0; i < len(pairs); i += 2 {
+        width = max(width, len(pairs[i]))
+    }
+    var b strings.Builder
+    for i := 0; i+1 < len(pairs); i += 2 {
+        b.WriteString(DetailLabelStyle.Render(fmt.Sprintf("%-*s", width, pairs[i])))
+        b.WriteString("  ")
+        b.WriteString(orNone(pairs[i+1]))
+        b.WriteString("\n")
+    }
+    return b.String()
+}
+
+func orNone(s string) string {
+    if s == "" {
+        return HelpStyle.Render("-")
+    }
+    return s
+}
+
+func section(title string) string {
+    return "\n" + HeaderStyle.Render(title) + "\n"
+}
+
+func renderConfig(d *docker.ContainerDetails) string {
+    c := d.Config
+    var b strings.Builder
+    b.WriteString(fields(
+        "ID", d.ID,
+        "Image", d.Image,
+        "Created", formatTime(d.Created),
+        "Entrypoint", strings.Join(c.Entrypoint, " "),
+        "Command", strings.Join(c.Command, " "),
+        "Working dir", c.WorkingDir,
+        "User", c.User,
+        "Hostname", c.Hostname,
+        "TTY", strconv.FormatBool(c.Tty),
+        "Stop signal", c.StopSignal,
+    ))
+
+    b.WriteString(section("Environment"))
+    if len(c.Env) == 0 {
+        b.WriteString(orNone(""))
+        b.WriteString("\n")
+    }
+    for _, env := range c.Env {
+        k, v, _ := strings.Cut(env, "=")
+        b.WriteString(DetailLabelStyle.Render(k) + "=" + v + "\n")
+    }
+
+    b.WriteString(section("Labels"))
+    keys := make([]string, 0, len(c.Labels))
+    for k := range c.Labels {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+    if len(keys) == 0 {
+        b.WriteString(orNone(""))
+        b.WriteString("\n")
+    }
+    for _, k := range keys {
+        b.WriteString(DetailLabelStyle.Render(k) + "=" + c.Labels[k] + "\n")
+    }
+    return b.String()
+}
+
+func renderMounts(mounts []docker.MountInfo) string {
+    if len(mounts) == 0 {
+        return HelpStyle.Render("No mounts")
+    }
+    var b strings.Builder
+    for i, mt := range mounts {
+        if i > 0 {
+            b.WriteString("\n")
+        }
+        access := "read-only"
+        if mt.RW {
+            access = "read-write"
+        }
+        b.WriteString(fields(
+            "Destination", mt.Destination,
+            "Source", mt.Source,
+            "Type", mt.Type,
+            "Name", mt.Name,
+            "Mode", strings.TrimSpace(mt.Mode+" "+access),
+        ))
+    }
+    return b.String()
+}
+
+func renderNetworks(networks []docker.NetworkEndpoint) string {
+    if len(networks) == 0 {
+        return HelpStyle.Render("Not connected to any network")
+    }
+    var b strings.Builder
+    for i, n := range networks {
+        if i > 0 {
+            b.WriteString("\n")
+        }
+        b.WriteString(fields(
+            "Network", n.Name,
+            "IP address", n.IPAddress,
+            "IPv6 address", n.IPv6Address,
+            "Gateway", n.Gateway,
+            "MAC address", n.MacAddress,
+            "Aliases", strings.Join(n.Aliases, ", "),
+        ))
+    }
+    return b.String()
+}
+
+func renderPorts(ports []docker.PortBinding) string {
+    if len(ports) == 0 {
+        return HelpStyle.Render("No ports exposed")
+    }
+    var pairs []string
+    for _, p := range ports {
+        host := "not published"
+        if p.HostPort != "" {
+            host = p.HostIP + ":" + p.HostPort
+            if p.HostIP == "" {
+                host = "*:" + p.HostPort
+            }
+        }
+        pairs = append(pairs, p.ContainerPort, "→ "+host)
+    }
+    return fields(pairs...)
+}
+
+func renderResources(d *docker.ContainerDetails) string {
+    r, rp := d.Resources, d.Restart
+
+    restart := rp.Name
+    if restart == "" {
+        restart = "no"
+    }
+    if rp.Name == "on-failure" && rp.MaxRetries > 0 {
+        restart += fmt.Sprintf(" (max %d retries)", rp.MaxRetries)
+    }
+
+    var b strings.Builder
+    b.WriteString(section("Restart policy"))
+    b.WriteString(fields(
+        "Policy", restart,
+        "Restart count", strconv.Itoa(rp.RestartCount),
+        "Auto remove", strconv.FormatBool(rp.AutoRemove),
+    ))
+
+    b.WriteString(section("Limits"))
+    b.WriteString(fields(
+        "CPUs", limit(r.CPUs > 0, strconv.FormatFloat(r.CPUs, 'f', -1, 64)),
+        "CPU shares", limit(r.CPUShares > 0, strconv.FormatInt(r.CPUShares, 10)),
+        "CPU set", limit(r.CpusetCpus != "", r.CpusetCpus),
+        "Memory", limit(r.Memory > 0, docker.FormatBytes(uint64(r.Memory))),
+        "Memory + swap", limit(r.MemorySwap > 0, docker.FormatBytes(uint64(r.MemorySwap))),
+        "PIDs", limit(r.PidsLimit > 0, strconv.FormatInt(r.PidsLimit, 10)),
+        "Privileged", strconv.FormatBool(r.Privileged),
+    ))
+    return strings.TrimPrefix(b.String(), "\n")
+}
+
+func limit(set bool, value string) string {
+    if !set {
+        return "unlimited"
+    }
+    return value
+}
+
+func renderState(s docker.ContainerState) string {
+    var b strings.Builder
+    b.WriteString(fields(
+        "Status", containerStatusStyle(stateStatus(s)).Render(s.Status),
+        "PID", limit(s.Pid > 0, strconv.Itoa(s.Pid)),
+        "Started", formatTime(s.StartedAt),
+        "Finished", formatTime(s.FinishedAt),
+        "Last exit code", strconv.Itoa(s.ExitCode),
+        "OOM killed", strconv.FormatBool(s.OOMKilled),
+        "Error", s.Error,
+    ))
+
+    b.WriteString(section("Health"))
+    if s.Health == nil {
+        b.WriteString(HelpStyle.Render("No health check"))
+        return b.String()
+    }
+    b.WriteString(fields(
+        "Status", s.Health.Status,
+        "Failing streak", strconv.Itoa(s.Health.FailingStreak),
+    ))
+    for _, check := range s.Health.Log {
+        result := ContainerRunningStyle.Render("passed")
+        if check.ExitCode != 0 {
+            result = ContainerStoppedStyle.Render(fmt.Sprintf("failed (%d)", check.ExitCode))
+        }
+        b.WriteString(fmt.Sprintf("\n%s %s\n", formatTime(check.Start), result))
+        if check.Output != "" {
+            b.WriteString(HelpStyle.Render(check.Output))
+            b.WriteString("\n")
+        }
+    }
+    return b.String()
+}
+
+// stateStatus maps an inspect state onto the list's status wording for
+// styling.
+func stateStatus(s docker.ContainerState) string {
+    switch {
+    case s.Paused:
+        return "Paused"
+    case s.Running:
+        return "Up"
+    case s.Status == "exited" || s.Status == "dead":
+        return "Exited"
+    }
+    return s.Status
+}
+
+func formatTime(t time.Time) string {
+    if t.IsZero() || t.Year() <= 1 {
+        return ""
+    }
+    return t.Local().Format("2006-01-02 15:04:05") + " (" + time.Since(t).Truncate(time.Second).String() + " ago)"
+}
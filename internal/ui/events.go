@@ -0,0 +1,114 @@
+package ui
+
+import (
+    "fmt"
+    "strings"
+    "time"
+
+    "docker-manager/internal/docker"
+
+    tea "github.com/charmbracelet/bubbletea"
+)
+
+// resyncInterval is how often the full container list is reloaded as a
+// safety net on top of the event stream.
+const resyncInterval = 30 * time.Second
+
+// pausedSuffix is how the daemon marks a paused container's status, e.g.
+// "Up 2 hours (Paused)".
+const pausedSuffix = " (Paused)"
+
+type containerEventMsg docker.ContainerEvent
+type resyncMsg time.Time
+
+func waitForEvent(events <-chan docker.ContainerEvent) tea.Cmd {
+    return func() tea.Msg {
+        ev, ok := <-events
+        if !ok {
+            return nil
+        }
+        return containerEventMsg(ev)
+    }
+}
+
+func resyncCmd() tea.Cmd {
+    return tea.Tick(resyncInterval, func(t time.Time) tea.Msg {
+        return resyncMsg(t)
+    })
+}
+
+// applyEvent updates the container list in place for a single event. It
+// returns a command when the event can't be applied locally and a full
+// reload is needed instead.
+func (m *Model) applyEvent(ev docker.ContainerEvent) tea.Cmd {
+    if ev.Action == docker.EventResync {
+        return m.refreshContainers()
+    }
+
+    // Filters are evaluated by the daemon, so let a reload decide what is
+    // visible after any change
+    if len(m.filters) > 0 {
+        return m.refreshContainers()
+    }
+
+    i := -1
+    for j, c := range m.containers {
+        if c.ID == ev.ID {
+            i = j
+            break
+        }
+    }
+
+    switch ev.Action {
+    case docker.EventCreate:
+        if i < 0 {
+            m.containers = append(m.containers, docker.ContainerInfo{
+                ID:      ev.ID,
+                Name:    ev.Name,
+                Image:   ev.Image,
+                Status:  "Created",
+                State:   "created",
+                Created: ev.Time,
+            })
+        }
+
+    case docker.EventDestroy:
+        if i >= 0 {
+            m.containers = append(m.containers[:i], m.containers[i+1:]...)
+        }
+        delete(m.marked, ev.ID)
+
+    default:
+        if i < 0 {
+            // An event for a container we haven't listed yet
+            return m.refreshContainers()
+        }
+        c := &m.containers[i]
+        switch ev.Action {
+        case docker.EventStart:
+            c.State = "running"
+            c.Status = "Up Less than a second"
+            c.Health = ""
+        case docker.EventDie:
+            c.State = "exited"
+            c.Status = fmt.Sprintf("Exited (%d) Less than a second ago", ev.ExitCode)
+            c.Health = ""
+            c.HasStats = false
+        case docker.EventHealth:
+            c.Health = ev.Health
+        case docker.EventRename:
+            c.Name = ev.Name
+        case docker.EventPause:
+            c.State = "paused"
+            if !strings.HasSuffix(c.Status, pausedSuffix) {
+                c.Status += pausedSuffix
+            }
+        case docker.EventUnpause:
+            c.State = "running"
+            c.Status = strings.TrimSuffix(c.Status, pausedSuffix)
+        }
+    }
+
+    m.updateTableRows()
+    return nil
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+    "testing"
+
+    "docker-manager/internal/docker"
+)
+
+// emit sends ev through the fake's event stream and hands the model what it
+// receives, the way the program loop would.
+func emit(t *testing.T, m Model, fake *docker.FakeRuntime, ev docker.ContainerEvent) Model {
+    t.Helper()
+    fake.Emit(ev)
+    updated, _ := m.Update(waitForEvent(m.events)())
+    return updated.(Model)
+}
+
+func TestEventsUpdateContainersInPlace(t *testing.T) {
+    m, fake := newTestModel(t)
+    listed := len(fake.Calls())
+
+    m = emit(t, m, fake, docker.ContainerEvent{Action: docker.EventHealth, ID: "bbbbbbbbbbbb", Health: "unhealthy"})
+    m = emit(t, m, fake, docker.ContainerEvent{Action: docker.EventDie, ID: "aaaaaaaaaaaa", ExitCode: 137})
+    m = emit(t, m, fake, docker.ContainerEvent{Action: docker.EventRename, ID: "bbbbbbbbbbbb", Name: "postgres"})
+    m = emit(t, m, fake, docker.ContainerEvent{Action: docker.EventCreate, ID: "cccccccccccc", Name: "job", Image: "busybox"})
+
+    if len(m.containers) != 3 {
+        t.Fatalf("containers = %+v, want web, db and the new job", m.containers)
+    }
+    web, db, job := m.containers[0], m.containers[1], m.containers[2]
+    if web.State != "exited" || web.Status != "Exited (137) Less than a second ago" {
+        t.Errorf("web = %s / %s, want exited with code 137", web.State, web.Status)
+    }
+    if db.Health != "unhealthy" || db.Name != "postgres" {
+        t.Errorf("db = %+v, want renamed to postgres and unhealthy", db)
+    }
+    if job.Name != "job" || job.State != "created" {
+        t.Errorf("job = %+v, want created", job)
+    }
+    if calls := fake.Calls(); len(calls) != listed {
+        t.Errorf("events went back to the daemon: %v", calls[listed:])
+    }
+
+    // Starting again clears the health of the previous run
+    m = emit(t, m, fake, docker.ContainerEvent{Action: docker.EventStart, ID: "bbbbbbbbbbbb"})
+    if db := m.containers[1]; db.State != "running" || db.Health != "" {
+        t.Errorf("db = %+v, want running with no health yet", db)
+    }
+}
+
+func TestDestroyEventRemovesSelectedRow(t *testing.T) {
+    m, fake := newTestModel(t)
+    if row := m.table.SelectedRow(); row == nil || row[0] != "aaaaaaaaaaaa" {
+        t.Fatalf("selected = %v, want web", row)
+    }
+
+    m = emit(t, m, fake, docker.ContainerEvent{Action: docker.EventDestroy, ID: "aaaaaaaaaaaa"})
+
+    if len(m.containers) != 1 || len(m.table.Rows()) != 1 {
+        t.Fatalf("containers = %+v, want only db", m.containers)
+    }
+    if row := m.table.SelectedRow(); row == nil || row[0] != "bbbbbbbbbbbb" {
+        t.Errorf("selected = %v, want db", row)
+    }
+}
+
+func TestDestroyEventOfLastSelectedRow(t *testing.T) {
+    m, fake := newTestModel(t)
+    m.table.MoveDown(1)
+
+    m = emit(t, m, fake, docker.ContainerEvent{Action: docker.EventDestroy, ID: "bbbbbbbbbbbb"})
+
+    if row := m.table.SelectedRow(); row == nil || row[0] != "aaaaaaaaaaaa" {
+        t.Errorf("selected = %v, want web", row)
+    }
+}
+
+func TestEventsThatNeedAReload(t *testing.T) {
+    byName := []docker.Filter{{Key: "name", Value: "web"}}
+    tests := []struct {
+        name    string
+        filters []docker.Filter
+        ev      docker.ContainerEvent
+    }{
+        {"unknown container", nil, docker.ContainerEvent{Action: docker.EventStart, ID: "ffffffffffff"}},
+        {"stream reconnected", nil, docker.ContainerEvent{Action: docker.EventResync}},
+        {"any change under a filter", byName, docker.ContainerEvent{Action: docker.EventDie, ID: "aaaaaaaaaaaa"}},
+    }
+    for _, tt := range tests {
+        m, _ := newTestModel(t)
+        m.filters = tt.filters
+
+        cmd := m.applyEvent(tt.ev)
+        if cmd == nil {
+            t.Errorf("%s: no reload", tt.name)
+            continue
+        }
+        if _, ok := cmd().(containersMsg); !ok {
+            t.Errorf("%s: the command doesn't reload the containers", tt.name)
+        }
+    }
+}
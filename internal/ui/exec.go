@@ -0,0 +1,80 @@
+package ui
+
+import (
+    "context"
+    "io"
+
+    "docker-manager/internal/docker"
+
+    tea "github.com/charmbracelet/bubbletea"
+)
+
+// shellCommand runs an interactive shell in a container. It is a
+// tea.ExecCommand, so the program releases the terminal while it runs and
+// takes it back when the shell exits.
+type shellCommand struct {
+    runtime docker.ContainerRuntime
+    id      string
+
+    stdin  io.Reader
+    stdout io.Writer
+    stderr io.Writer
+
+    // exitCode is the shell's, once Run has returned without error
+    exitCode int
+}
+
+func (c *shellCommand) SetStdin(r io.Reader)  { c.stdin = r }
+func (c *shellCommand) SetStdout(w io.Writer) { c.stdout = w }
+func (c *shellCommand) SetStderr(w io.Writer) { c.stderr = w }
+
+func (c *shellCommand) Run() error {
+    width, height := docker.TerminalSize(c.stdin)
+    session, err := c.runtime.ExecContainer(context.Background(), c.id, docker.ExecOptions{
+        Cmd:    docker.ShellCommand,
+        Tty:    true,
+        Stdin:  true,
+        Width:  width,
+        Height: height,
+    })
+    if err != nil {
+        return err
+    }
+    defer session.Close()
+
+    if err := session.Attach(c.stdin, c.stdout, c.stderr); err != nil {
+        return err
+    }
+    c.exitCode, err = session.ExitCode()
+    return err
+}
+
+type shellExitMsg struct {
+    name     string
+    exitCode int
+    err      error
+}
+
+// openShell suspends the UI and attaches the terminal to a shell in c.
+func (m *Model) openShell(c docker.ContainerInfo) tea.Cmd {
+    if c.State != "running" {
+        return m.notify(notifyError, "%s is not running", c.Name)
+    }
+    shell := &shellCommand{runtime: m.runtime, id: c.ID}
+    return tea.Exec(shell, func(err error) tea.Msg {
+        return shellExitMsg{name: c.Name, exitCode: shell.exitCode, err: err}
+    })
+}
+
+func (m *Model) updateShellExit(msg shellExitMsg) tea.Cmd {
+    cmds := []tea.Cmd{m.refreshContainers()}
+    switch {
+    case docker.IsConnectionError(msg.err):
+        cmds = append(cmds, m.disconnect(msg.err))
+    case msg.err != nil:
+        cmds = append(cmds, m.notify(notifyError, "shell in %s failed: %v", msg.name, msg.err))
+    case msg.exitCode != 0:
+        cmds = append(cmds, m.notify(notifyInfo, "shell in %s exited with code %d", msg.name, msg.exitCode))
+    }
+    return tea.Batch(cmds...)
+}
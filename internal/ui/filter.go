@@ -0,0 +1,25 @@
+package ui
+
+import (
+    "strings"
+
+    "docker-manager/internal/docker"
+)
+
+// parseFilterInput turns the filter prompt into daemon filters. Words of the
+// form key=value are passed through, plain words match container names.
+func parseFilterInput(input string) ([]docker.Filter, error) {
+    var filters []docker.Filter
+    for _, word := range strings.Fields(input) {
+        if !strings.Contains(word, "=") {
+            filters = append(filters, docker.Filter{Key: "name", Value: word})
+            continue
+        }
+        f, err := docker.ParseFilter(word)
+        if err != nil {
+            return nil, err
+        }
+        filters = append(filters, f)
+    }
+    return filters, nil
+}
@@ -0,0 +1,31 @@
+package ui
+
+import (
+    "testing"
+
+    "docker-manager/internal/docker"
+)
+
+func TestParseFilterInput(t *testing.T) {
+    got, err := parseFilterInput("web  label=tier=db status=running")
+    if err != nil {
+        t.Fatal(err)
+    }
+    want := []docker.Filter{
+        {Key: "name", Value: "web"},
+        {Key: "label", Value: "tier=db"},
+        {Key: "status", Value: "running"},
+    }
+    if len(got) != len(want) {
+        t.Fatalf("got %+v, want %+v", got, want)
+    }
+    for i := range want {
+        if got[i] != want[i] {
+            t.Errorf("filter %d = %+v, want %+v", i, got[i], want[i])
+        }
+    }
+
+    if _, err := parseFilterInput("web color=red"); err == nil {
+        t.Error("unknown key accepted")
+    }
+}
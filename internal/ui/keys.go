@@ -16,6 +16,39 @@ type keyMap struct {
     Help    key.Binding
     Back    key.Binding
     Enter   key.Binding
+
+    Pause   key.Binding
+    Unpause key.Binding
+    Kill    key.Binding
+    Rename  key.Binding
+    Shell   key.Binding
+
+    Mark         key.Binding
+    SelectAll    key.Binding
+    MarkByFilter key.Binding
+    MergedLogs   key.Binding
+
+    History key.Binding
+
+    NextTab key.Binding
+    PrevTab key.Binding
+
+    Confirm       key.Binding
+    Cancel        key.Binding
+    ToggleForce   key.Binding
+    ToggleVolumes key.Binding
+
+    PauseLogs key.Binding
+    Bottom    key.Binding
+
+    Search      key.Binding
+    NextMatch   key.Binding
+    PrevMatch   key.Binding
+    RegexToggle key.Binding
+    OnlyMatches key.Binding
+    LevelFilter key.Binding
+    PrettyJSON  key.Binding
+    Export      key.Binding
 }
 
 var Keys = keyMap{
@@ -71,4 +104,108 @@ var Keys = keyMap{
         key.WithKeys("enter"),
         key.WithHelp("enter", "enter"),
     ),
+    Pause: key.NewBinding(
+        key.WithKeys("p"),
+        key.WithHelp("p", "pause"),
+    ),
+    Unpause: key.NewBinding(
+        key.WithKeys("u"),
+        key.WithHelp("u", "unpause"),
+    ),
+    Kill: key.NewBinding(
+        key.WithKeys("K"),
+        key.WithHelp("K", "kill with signal"),
+    ),
+    Rename: key.NewBinding(
+        key.WithKeys("R"),
+        key.WithHelp("R", "rename"),
+    ),
+    Shell: key.NewBinding(
+        key.WithKeys("e"),
+        key.WithHelp("e", "open a shell"),
+    ),
+    SelectAll: key.NewBinding(
+        key.WithKeys("a"),
+        key.WithHelp("a", "mark all"),
+    ),
+    MarkByFilter: key.NewBinding(
+        key.WithKeys("m"),
+        key.WithHelp("m", "mark matching"),
+    ),
+    Mark: key.NewBinding(
+        key.WithKeys(" "),
+        key.WithHelp("space", "mark"),
+    ),
+    MergedLogs: key.NewBinding(
+        key.WithKeys("M"),
+        key.WithHelp("M", "merged logs of marked"),
+    ),
+    NextTab: key.NewBinding(
+        key.WithKeys("tab", "right"),
+        key.WithHelp("tab", "next tab"),
+    ),
+    PrevTab: key.NewBinding(
+        key.WithKeys("shift+tab", "left"),
+        key.WithHelp("shift+tab", "previous tab"),
+    ),
+    History: key.NewBinding(
+        key.WithKeys("H"),
+        key.WithHelp("H", "action history"),
+    ),
+    Confirm: key.NewBinding(
+        key.WithKeys("y", "Y"),
+        key.WithHelp("y", "confirm"),
+    ),
+    Cancel: key.NewBinding(
+        key.WithKeys("n", "N"),
+        key.WithHelp("n", "cancel"),
+    ),
+    ToggleForce: key.NewBinding(
+        key.WithKeys("f"),
+        key.WithHelp("f", "toggle force"),
+    ),
+    ToggleVolumes: key.NewBinding(
+        key.WithKeys("v"),
+        key.WithHelp("v", "toggle volumes"),
+    ),
+    PauseLogs: key.NewBinding(
+        key.WithKeys("p"),
+        key.WithHelp("p", "pause/resume logs"),
+    ),
+    Bottom: key.NewBinding(
+        key.WithKeys("G", "end"),
+        key.WithHelp("G", "follow"),
+    ),
+    Search: key.NewBinding(
+        key.WithKeys("/"),
+        key.WithHelp("/", "search logs"),
+    ),
+    NextMatch: key.NewBinding(
+        key.WithKeys("n"),
+        key.WithHelp("n", "next match"),
+    ),
+    PrevMatch: key.NewBinding(
+        key.WithKeys("N"),
+        key.WithHelp("N", "previous match"),
+    ),
+    RegexToggle: key.NewBinding(
+        key.WithKeys("ctrl+r"),
+        key.WithHelp("ctrl+r", "toggle regex search"),
+    ),
+    OnlyMatches: key.NewBinding(
+        key.WithKeys("o"),
+        key.WithHelp("o", "only matching lines"),
+    ),
+    LevelFilter: key.NewBinding(
+        key.WithKeys("L"),
+        key.WithHelp("L", "cycle minimum level"),
+    ),
+    PrettyJSON: key.NewBinding(
+        key.WithKeys("J"),
+        key.WithHelp("J", "pretty-print JSON"),
+    ),
+    Export: key.NewBinding(
+        key.WithKeys("w"),
+        key.WithHelp("w", "save logs to a file"),
+    ),
 }
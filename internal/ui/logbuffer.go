@@ -0,0 +1,56 @@
+package ui
+
+import "docker-manager/internal/docker"
+
+// logBufferSize bounds how many lines a log view keeps in memory.
+const logBufferSize = 5000
+
+// logEntry is a buffered line with its level detected once on arrival.
+type logEntry struct {
+    line  docker.LogLine
+    level logLevel
+}
+
+// logBuffer is a fixed-size ring buffer that drops the oldest lines once
+// full.
+type logBuffer struct {
+    entries []logEntry
+    start   int
+    count   int
+}
+
+func newLogBuffer(capacity int) *logBuffer {
+    return &logBuffer{entries: make([]logEntry, capacity)}
+}
+
+func (b *logBuffer) Append(line docker.LogLine) {
+    end := (b.start + b.count) % len(b.entries)
+    b.entries[end] = logEntry{line: line, level: detectLevel(line.Text)}
+    if b.count < len(b.entries) {
+        b.count++
+    } else {
+        b.start = (b.start + 1) % len(b.entries)
+    }
+}
+
+func (b *logBuffer) Len() int {
+    return b.count
+}
+
+// Entries returns the buffered entries, oldest first.
+func (b *logBuffer) Entries() []logEntry {
+    result := make([]logEntry, b.count)
+    for i := range result {
+        result[i] = b.entries[(b.start+i)%len(b.entries)]
+    }
+    return result
+}
+
+// Lines returns the buffered lines, oldest first.
+func (b *logBuffer) Lines() []docker.LogLine {
+    result := make([]docker.LogLine, b.count)
+    for i := range result {
+        result[i] = b.entries[(b.start+i)%len(b.entries)].line
+    }
+    return result
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+    "fmt"
+    "strings"
+
+    "docker-manager/internal/docker"
+
+    "github.com/charmbracelet/bubbles/key"
+    "github.com/charmbracelet/bubbles/textinput"
+    tea "github.com/charmbracelet/bubbletea"
+)
+
+type logExportMsg struct {
+    path  string
+    count int
+    err   error
+}
+
+// logExport is what to save, as entered at the export prompt.
+type logExport struct {
+    path string
+    // history re-reads the logs from the daemon instead of saving the
+    // buffer, optionally limited to since/until
+    history bool
+    since   string
+    until   string
+}
+
+func newExportInput() textinput.Model {
+    ti := textinput.New()
+    ti.Prompt = "Save to: "
+    ti.Placeholder = "file [all] [since=1h] [until=10m], e.g. incident.jsonl.gz since=2h"
+    ti.CharLimit = 200
+    ti.Width = 60
+    return ti
+}
+
+// parseExportInput reads "file [all] [since=...] [until=...]". Without all,
+// since or until the current buffer is saved.
+func parseExportInput(input string) (logExport, error) {
+    var export logExport
+    for _, word := range strings.Fields(input) {
+        k, v, ok := strings.Cut(word, "=")
+        switch {
+        case ok && k == "since":
+            export.since, export.history = v, true
+        case ok && k == "until":
+            export.until, export.history = v, true
+        case word == "all":
+            export.history = true
+        case export.path == "":
+            export.path = word
+        default:
+            return logExport{}, fmt.Errorf("unexpected %q", word)
+        }
+    }
+    if export.path == "" {
+        return logExport{}, fmt.Errorf("enter a file name")
+    }
+    return export, nil
+}
+
+// updateExportInput handles keys while the export prompt is open.
+func (m *Model) updateExportInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+    s := &m.logs
+    switch {
+    case key.Matches(msg, Keys.Enter):
+        export, err := parseExportInput(s.export.Value())
+        if err != nil {
+            s.exportErr = err
+            return *m, nil
+        }
+        s.exporting = false
+        s.export.Blur()
+        s.exportErr = nil
+        s.exportResult = "Saving to " + export.path + "..."
+        return *m, m.exportLogs(export)
+
+    case key.Matches(msg, Keys.Back):
+        s.exporting = false
+        s.exportErr = nil
+        s.export.Blur()
+        return *m, nil
+    }
+
+    var cmd tea.Cmd
+    s.export, cmd = s.export.Update(msg)
+    return *m, cmd
+}
+
+// exportLogs writes the buffer, or the history read back with
+// GetContainerLogs, to a file. The format follows the file name: .jsonl
+// for JSON lines, .gz to compress.
+func (m *Model) exportLogs(export logExport) tea.Cmd {
+    opts := docker.ExportOptions{
+        Format:     docker.ExportFormatFor(export.path),
+        Timestamps: true,
+    }
+
+    if !export.history {
+        lines := m.logs.buffer.Lines()
+        return func() tea.Msg {
+            err := docker.ExportLogs(export.path, lines, opts)
+            return logExportMsg{path: export.path, count: len(lines), err: err}
+        }
+    }
+
+    runtime, containers := m.runtime, m.logs.containers
+    logOpts := docker.LogOptions{Tail: "all", Since: export.since, Until: export.until}
+    return func() tea.Msg {
+        lines, err := docker.CollectLogs(runtime, containers, logOpts)
+        if err == nil {
+            err = docker.ExportLogs(export.path, lines, opts)
+        }
+        return logExportMsg{path: export.path, count: len(lines), err: err}
+    }
+}
@@ -0,0 +1,380 @@
+package ui
+
+import (
+    "fmt"
+    "regexp"
+    "strings"
+
+    "docker-manager/internal/docker"
+
+    "github.com/charmbracelet/bubbles/key"
+    "github.com/charmbracelet/bubbles/textinput"
+    tea "github.com/charmbracelet/bubbletea"
+    "github.com/charmbracelet/lipgloss"
+)
+
+// logBatchSize caps how many buffered lines are applied per message so a
+// chatty container doesn't starve the UI.
+const logBatchSize = 500
+
+// logState is the live log view of one container, or of several merged
+// into one stream.
+type logState struct {
+    // gen identifies the current stream; messages from older streams
+    // are dropped
+    gen        int
+    containers []docker.ContainerInfo
+    stream     *docker.LogStream
+    buffer     *logBuffer
+    // prefixes holds the rendered name prefix of each container in a
+    // merged view
+    prefixes map[string]string
+
+    paused bool
+    // follow keeps the viewport pinned to the newest line until the user
+    // scrolls up
+    follow bool
+    ended  bool
+    err    error
+
+    // search is the "/" prompt; matcher is the applied query
+    search    textinput.Model
+    searching bool
+    regex     bool
+    matcher   *regexp.Regexp
+    searchErr error
+    // matchRows holds the viewport row of each matching line, matchIdx
+    // the current one
+    matchRows   []int
+    matchIdx    int
+    onlyMatches bool
+
+    minLevel   logLevel
+    prettyJSON bool
+
+    // export is the "w" prompt; exportResult reports the last save
+    export       textinput.Model
+    exporting    bool
+    exportErr    error
+    exportResult string
+}
+
+type logStreamMsg struct {
+    gen    int
+    stream *docker.LogStream
+}
+
+type logLinesMsg struct {
+    gen   int
+    lines []docker.LogLine
+}
+
+type logEndMsg struct {
+    gen int
+    err error
+}
+
+// openLogs switches to the log view and starts following containers,
+// merging their logs if there is more than one.
+func (m *Model) openLogs(containers ...docker.ContainerInfo) tea.Cmd {
+    closeCmd := m.closeLogs()
+
+    m.currentView = LogsView
+    m.logs = logState{
+        gen:        m.logs.gen + 1,
+        containers: containers,
+        buffer:     newLogBuffer(logBufferSize),
+        prefixes:   logPrefixes(containers),
+        follow:     true,
+        search:     newSearchInput(),
+        export:     newExportInput(),
+    }
+    m.viewport.SetContent("")
+
+    ctx, runtime, gen := m.ctx, m.runtime, m.logs.gen
+    opts := docker.LogOptions{Tail: "100", Follow: true}
+    return tea.Batch(closeCmd, func() tea.Msg {
+        var stream *docker.LogStream
+        var err error
+        if len(containers) == 1 {
+            stream, err = runtime.StreamLogs(ctx, containers[0].ID, opts)
+        } else {
+            stream, err = docker.MergeLogs(ctx, runtime, containers, opts)
+        }
+        if err != nil {
+            return logEndMsg{gen: gen, err: err}
+        }
+        return logStreamMsg{gen: gen, stream: stream}
+    })
+}
+
+// logPrefixes gives each container of a merged view a padded, coloured
+// "name | " prefix. A single container needs none.
+func logPrefixes(containers []docker.ContainerInfo) map[string]string {
+    if len(containers) < 2 {
+        return nil
+    }
+
+    width := 0
+    for _, c := range containers {
+        width = max(width, len(c.Name))
+    }
+
+    prefixes := make(map[string]string, len(containers))
+    for i, c := range containers {
+        style := lipgloss.NewStyle().Foreground(LogPrefixColors[i%len(LogPrefixColors)])
+        prefixes[c.Name] = style.Render(c.Name + strings.Repeat(" ", width-len(c.Name)) + " | ")
+    }
+    return prefixes
+}
+
+// closeLogs stops the current stream, if any. Closing waits for the reader
+// to finish, so it happens off the update loop.
+func (m *Model) closeLogs() tea.Cmd {
+    stream := m.logs.stream
+    m.logs.stream = nil
+    if stream == nil {
+        return nil
+    }
+    return func() tea.Msg {
+        stream.Close()
+        return nil
+    }
+}
+
+func waitForLogLines(gen int, stream *docker.LogStream) tea.Cmd {
+    return func() tea.Msg {
+        line, ok := <-stream.Lines
+        if !ok {
+            return logEndMsg{gen: gen, err: stream.Err()}
+        }
+
+        // Take whatever else is already waiting
+        lines := []docker.LogLine{line}
+        for len(lines) < logBatchSize {
+            select {
+            case line, ok := <-stream.Lines:
+                if !ok {
+                    return logLinesMsg{gen: gen, lines: lines}
+                }
+                lines = append(lines, line)
+            default:
+                return logLinesMsg{gen: gen, lines: lines}
+            }
+        }
+        return logLinesMsg{gen: gen, lines: lines}
+    }
+}
+
+func (m *Model) updateLogStream(msg tea.Msg) tea.Cmd {
+    switch msg := msg.(type) {
+    case logStreamMsg:
+        if msg.gen != m.logs.gen {
+            stream := msg.stream
+            return func() tea.Msg {
+                stream.Close()
+                return nil
+            }
+        }
+        m.logs.stream = msg.stream
+        return waitForLogLines(msg.gen, msg.stream)
+
+    case logLinesMsg:
+        if msg.gen != m.logs.gen || m.logs.stream == nil {
+            return nil
+        }
+        for _, line := range msg.lines {
+            m.logs.buffer.Append(line)
+        }
+        if !m.logs.paused {
+            m.refreshLogView()
+        }
+        return waitForLogLines(msg.gen, m.logs.stream)
+
+    case logEndMsg:
+        if msg.gen != m.logs.gen {
+            return nil
+        }
+        m.logs.ended = true
+        m.logs.err = msg.err
+
+    case logExportMsg:
+        if msg.err != nil {
+            m.logs.exportResult = fmt.Sprintf("Saving %s failed: %v", msg.path, msg.err)
+            return m.notify(notifyError, "%s", m.logs.exportResult)
+        }
+        m.logs.exportResult = fmt.Sprintf("Saved %d lines to %s", msg.count, msg.path)
+        return m.notify(notifySuccess, "%s", m.logs.exportResult)
+    }
+    return nil
+}
+
+func (m *Model) refreshLogView() {
+    m.viewport.SetContent(m.renderLogs())
+    if m.logs.follow {
+        m.viewport.GotoBottom()
+    }
+}
+
+func (m *Model) updateLogsView(msg tea.KeyMsg) (Model, tea.Cmd) {
+    if m.logs.searching {
+        return m.updateSearchInput(msg)
+    }
+    if m.logs.exporting {
+        return m.updateExportInput(msg)
+    }
+
+    switch {
+    case key.Matches(msg, Keys.Search):
+        m.logs.searching = true
+        m.logs.search.SetValue("")
+        return *m, m.logs.search.Focus()
+
+    case key.Matches(msg, Keys.History):
+        m.openHistory()
+        return *m, nil
+
+    case key.Matches(msg, Keys.Export):
+        m.logs.exporting = true
+        m.logs.export.SetValue("")
+        return *m, m.logs.export.Focus()
+
+    case key.Matches(msg, Keys.NextMatch):
+        m.gotoMatch(m.logs.matchIdx + 1)
+        return *m, nil
+
+    case key.Matches(msg, Keys.PrevMatch):
+        m.gotoMatch(m.logs.matchIdx - 1)
+        return *m, nil
+
+    case key.Matches(msg, Keys.OnlyMatches):
+        m.logs.onlyMatches = !m.logs.onlyMatches
+        m.refreshLogView()
+        return *m, nil
+
+    case key.Matches(msg, Keys.LevelFilter):
+        m.logs.minLevel = nextLevel(m.logs.minLevel)
+        m.refreshLogView()
+        return *m, nil
+
+    case key.Matches(msg, Keys.PrettyJSON):
+        m.logs.prettyJSON = !m.logs.prettyJSON
+        m.refreshLogView()
+        return *m, nil
+
+    case key.Matches(msg, Keys.Back) && m.logs.matcher != nil:
+        // First esc clears the search, the next one leaves the view
+        m.logs.matcher = nil
+        m.logs.onlyMatches = false
+        m.refreshLogView()
+        return *m, nil
+
+    case key.Matches(msg, Keys.Back):
+        m.currentView = ContainersView
+        m.viewport.SetContent("")
+        return *m, m.closeLogs()
+
+    case key.Matches(msg, Keys.Quit):
+        return *m, m.quit()
+
+    case key.Matches(msg, Keys.PauseLogs):
+        m.logs.paused = !m.logs.paused
+        if !m.logs.paused {
+            m.refreshLogView()
+        }
+        return *m, nil
+
+    case key.Matches(msg, Keys.Bottom):
+        m.logs.follow = true
+        m.viewport.GotoBottom()
+        return *m, nil
+    }
+
+    var cmd tea.Cmd
+    m.viewport, cmd = m.viewport.Update(msg)
+    // Scrolling up stops auto-scroll, scrolling back to the end resumes it
+    m.logs.follow = m.viewport.AtBottom()
+    return *m, cmd
+}
+
+func (m Model) logsView() string {
+    var b strings.Builder
+
+    var names []string
+    for _, c := range m.logs.containers {
+        names = append(names, c.Name)
+    }
+    title := "📋 Logs - " + strings.Join(names, ", ")
+    switch {
+    case m.logs.err != nil:
+        title += fmt.Sprintf(" (stream failed: %v)", m.logs.err)
+    case m.logs.ended:
+        title += " (stream ended)"
+    case m.logs.paused:
+        title += " (paused)"
+    case !m.logs.follow:
+        title += " (scrolled, G to follow)"
+    }
+    b.WriteString(TitleStyle.Render(title))
+    b.WriteString("\n\n")
+
+    b.WriteString(m.viewport.View())
+    b.WriteString("\n\n")
+
+    b.WriteString(m.logStatusLine())
+    b.WriteString("\n")
+
+    b.WriteString(HelpStyle.Render("/: Search • n/N: Next/prev match • o: Only matches • L: Level • J: Pretty JSON • w: Save • H: History • G: Follow • p: Pause/resume • esc: Back • q: Quit"))
+
+    return b.String()
+}
+
+// logStatusLine shows the search prompt while typing, otherwise the active
+// search and view options.
+func (m Model) logStatusLine() string {
+    s := m.logs
+    if s.searching {
+        line := s.search.View()
+        if s.regex {
+            line += HelpStyle.Render(" (regex, ctrl+r to toggle)")
+        } else {
+            line += HelpStyle.Render(" (ctrl+r for regex)")
+        }
+        if s.searchErr != nil {
+            line += "\n" + ContainerStoppedStyle.Render("Invalid pattern: "+s.searchErr.Error())
+        }
+        return line
+    }
+    if s.exporting {
+        line := s.export.View()
+        if s.exportErr != nil {
+            line += "\n" + ContainerStoppedStyle.Render(s.exportErr.Error())
+        }
+        return line
+    }
+
+    var parts []string
+    if s.matcher != nil {
+        match := fmt.Sprintf("%q: no matches", s.matcher.String())
+        if s.regex {
+            match = fmt.Sprintf("/%s/: no matches", s.matcher.String())
+        }
+        if n := len(s.matchRows); n > 0 {
+            match = strings.TrimSuffix(match, "no matches") + fmt.Sprintf("%d/%d", s.matchIdx+1, n)
+        }
+        parts = append(parts, match)
+    }
+    if s.onlyMatches {
+        parts = append(parts, "only matches")
+    }
+    if s.minLevel != levelUnknown {
+        parts = append(parts, "level ≥ "+s.minLevel.String())
+    }
+    if s.prettyJSON {
+        parts = append(parts, "pretty JSON")
+    }
+    if s.exportResult != "" {
+        parts = append(parts, s.exportResult)
+    }
+    return StatusBarStyle.Render(strings.Join(append([]string{"Logs"}, parts...), " • "))
+}
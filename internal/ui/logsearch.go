@@ -0,0 +1,261 @@
+package ui
+
+import (
+    "bytes"
+    "encoding/json"
+    "regexp"
+    "strings"
+
+    "docker-manager/internal/docker"
+
+    "github.com/charmbracelet/bubbles/key"
+    "github.com/charmbracelet/bubbles/textinput"
+    tea "github.com/charmbracelet/bubbletea"
+    "github.com/charmbracelet/lipgloss"
+)
+
+type logLevel int
+
+const (
+    levelUnknown logLevel = iota
+    levelDebug
+    levelInfo
+    levelWarn
+    levelError
+)
+
+func (l logLevel) String() string {
+    switch l {
+    case levelDebug:
+        return "DEBUG"
+    case levelInfo:
+        return "INFO"
+    case levelWarn:
+        return "WARN"
+    case levelError:
+        return "ERROR"
+    }
+    return "ALL"
+}
+
+// levelScanWidth limits level detection in plain text to the start of the
+// line, where loggers put it, so "no error" in a message doesn't count.
+const levelScanWidth = 64
+
+var levelPattern = regexp.MustCompile(`(?i)\b(fatal|panic|crit|critical|error|err|eror|warn|warning|info|inf|notice|debug|dbg|trace)\b`)
+
+// detectLevel recognises common log levels in structured JSON lines and in
+// plain text such as "ERROR ...", "[warn] ..." or "level=info".
+func detectLevel(text string) logLevel {
+    if fields, ok := parseJSONLine(text); ok {
+        for _, k := range []string{"level", "lvl", "severity", "log.level"} {
+            if v, ok := fields[k].(string); ok {
+                return parseLevel(v)
+            }
+        }
+    }
+
+    if len(text) > levelScanWidth {
+        text = text[:levelScanWidth]
+    }
+    return parseLevel(levelPattern.FindString(text))
+}
+
+func parseLevel(s string) logLevel {
+    switch strings.ToLower(s) {
+    case "fatal", "panic", "crit", "critical", "error", "err", "eror":
+        return levelError
+    case "warn", "warning":
+        return levelWarn
+    case "info", "inf", "notice":
+        return levelInfo
+    case "debug", "dbg", "trace":
+        return levelDebug
+    }
+    return levelUnknown
+}
+
+func parseJSONLine(text string) (map[string]interface{}, bool) {
+    if !strings.HasPrefix(strings.TrimSpace(text), "{") {
+        return nil, false
+    }
+    var fields map[string]interface{}
+    if err := json.Unmarshal([]byte(text), &fields); err != nil {
+        return nil, false
+    }
+    return fields, true
+}
+
+// prettyJSON indents JSON lines and leaves anything else untouched.
+func prettyJSON(text string) string {
+    trimmed := strings.TrimSpace(text)
+    if !strings.HasPrefix(trimmed, "{") {
+        return text
+    }
+    var buf bytes.Buffer
+    if err := json.Indent(&buf, []byte(trimmed), "", "  "); err != nil {
+        return text
+    }
+    return buf.String()
+}
+
+func levelStyle(level logLevel) lipgloss.Style {
+    switch level {
+    case levelError:
+        return LogErrorStyle
+    case levelWarn:
+        return LogWarnStyle
+    case levelInfo:
+        return LogInfoStyle
+    case levelDebug:
+        return LogDebugStyle
+    }
+    return lipgloss.NewStyle()
+}
+
+// nextLevel cycles the minimum level shown: all, debug, info, warn, error.
+func nextLevel(l logLevel) logLevel {
+    if l == levelError {
+        return levelUnknown
+    }
+    return l + 1
+}
+
+func newSearchInput() textinput.Model {
+    ti := textinput.New()
+    ti.Prompt = "/"
+    ti.Placeholder = "search"
+    ti.CharLimit = 200
+    ti.Width = 50
+    return ti
+}
+
+// compileSearch builds the matcher for a query. Plain queries match
+// literally and ignore case.
+func compileSearch(query string, regex bool) (*regexp.Regexp, error) {
+    if query == "" {
+        return nil, nil
+    }
+    if regex {
+        return regexp.Compile(query)
+    }
+    return regexp.Compile("(?i)" + regexp.QuoteMeta(query))
+}
+
+// highlight renders row in base, with every match picked out.
+func highlight(row string, matcher *regexp.Regexp, base, match lipgloss.Style) string {
+    if matcher == nil {
+        return base.Render(row)
+    }
+    locs := matcher.FindAllStringIndex(row, -1)
+    if len(locs) == 0 {
+        return base.Render(row)
+    }
+
+    var b strings.Builder
+    last := 0
+    for _, loc := range locs {
+        if loc[0] == loc[1] {
+            // Skip empty matches such as "x*"
+            continue
+        }
+        b.WriteString(base.Render(row[last:loc[0]]))
+        b.WriteString(match.Render(row[loc[0]:loc[1]]))
+        last = loc[1]
+    }
+    b.WriteString(base.Render(row[last:]))
+    return b.String()
+}
+
+// renderLogs builds the viewport content from the buffer, applying the level
+// filter, search and JSON formatting, and records which rows match.
+func (m *Model) renderLogs() string {
+    s := &m.logs
+    s.matchRows = s.matchRows[:0]
+
+    var rows []string
+    for _, entry := range s.buffer.Entries() {
+        if s.minLevel != levelUnknown && entry.level < s.minLevel {
+            continue
+        }
+
+        text := entry.line.Text
+        if s.prettyJSON {
+            text = prettyJSON(text)
+        }
+
+        matched := s.matcher != nil && s.matcher.MatchString(text)
+        if s.onlyMatches && s.matcher != nil && !matched {
+            continue
+        }
+
+        base := levelStyle(entry.level)
+        if entry.line.Stream == docker.StreamStderr && entry.level == levelUnknown {
+            base = LogStderrStyle
+        }
+        matchStyle := SearchMatchStyle
+        if matched {
+            if len(s.matchRows) == s.matchIdx {
+                matchStyle = CurrentMatchStyle
+            }
+            s.matchRows = append(s.matchRows, len(rows))
+        }
+
+        prefix := s.prefixes[entry.line.Container]
+        for _, row := range strings.Split(text, "\n") {
+            rows = append(rows, prefix+highlight(row, s.matcher, base, matchStyle))
+        }
+    }
+    return strings.Join(rows, "\n")
+}
+
+// updateSearchInput handles keys while the search prompt is open.
+func (m *Model) updateSearchInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+    s := &m.logs
+    switch {
+    case key.Matches(msg, Keys.Enter):
+        matcher, err := compileSearch(s.search.Value(), s.regex)
+        if err != nil {
+            s.searchErr = err
+            return *m, nil
+        }
+        s.searching = false
+        s.search.Blur()
+        s.searchErr = nil
+        s.matcher = matcher
+        // Start from the most recent match
+        s.matchIdx = -1
+        m.refreshLogView()
+        if len(s.matchRows) > 0 {
+            m.gotoMatch(len(s.matchRows) - 1)
+        }
+        return *m, nil
+
+    case key.Matches(msg, Keys.Back):
+        s.searching = false
+        s.searchErr = nil
+        s.search.Blur()
+        return *m, nil
+
+    case key.Matches(msg, Keys.RegexToggle):
+        s.regex = !s.regex
+        return *m, nil
+    }
+
+    var cmd tea.Cmd
+    s.search, cmd = s.search.Update(msg)
+    return *m, cmd
+}
+
+// gotoMatch scrolls to the i-th match and stops auto-scroll so it stays
+// in view.
+func (m *Model) gotoMatch(i int) {
+    s := &m.logs
+    if len(s.matchRows) == 0 {
+        return
+    }
+    s.matchIdx = (i + len(s.matchRows)) % len(s.matchRows)
+    s.follow = false
+    m.refreshLogView()
+    m.viewport.SetYOffset(s.matchRows[s.matchIdx])
+}
@@ -0,0 +1,126 @@
+package ui
+
+import (
+    "strings"
+    "testing"
+
+    "docker-manager/internal/docker"
+
+    tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDetectLevel(t *testing.T) {
+    tests := []struct {
+        text string
+        want logLevel
+    }{
+        {"ERROR failed to connect", levelError},
+        {"2024-01-02T13:00:00Z [warn] disk almost full", levelWarn},
+        {"time=13:00:00 level=info msg=started", levelInfo},
+        {"[DBG] cache miss", levelDebug},
+        {"PANIC: nil map", levelError},
+        {"NOTICE: ready to accept connections", levelInfo},
+        {"request handled in 3ms", levelUnknown},
+        // Only the start of a line is scanned
+        {strings.Repeat("x", levelScanWidth) + " error", levelUnknown},
+        {`{"level":"warn","msg":"slow query"}`, levelWarn},
+        {`{"severity":"ERROR","message":"boom"}`, levelError},
+        {`{"lvl":"dbg"}`, levelDebug},
+        // The JSON field wins over words in the message
+        {`{"level":"info","msg":"error budget ok"}`, levelInfo},
+        {`{"msg":"no level"}`, levelUnknown},
+    }
+    for _, tt := range tests {
+        if got := detectLevel(tt.text); got != tt.want {
+            t.Errorf("detectLevel(%q) = %v, want %v", tt.text, got, tt.want)
+        }
+    }
+}
+
+func TestCompileSearch(t *testing.T) {
+    if m, err := compileSearch("", false); m != nil || err != nil {
+        t.Errorf("empty query = %v, %v; want no matcher", m, err)
+    }
+
+    plain, err := compileSearch("a(b", false)
+    if err != nil {
+        t.Fatalf("plain query: %v", err)
+    }
+    if !plain.MatchString("xA(By") {
+        t.Error("plain queries should match literally and ignore case")
+    }
+
+    if _, err := compileSearch("a(b", true); err == nil {
+        t.Error("invalid regex accepted")
+    }
+    re, err := compileSearch(`5\d\d$`, true)
+    if err != nil || !re.MatchString("GET / 503") || re.MatchString("GET / 200") {
+        t.Errorf("regex query = %v, %v", re, err)
+    }
+}
+
+// newLogTestModel returns a model whose log view shows lines.
+func newLogTestModel(t *testing.T, lines ...string) Model {
+    t.Helper()
+    m, _ := newTestModel(t)
+    m.currentView = LogsView
+    m.logs = logState{buffer: newLogBuffer(logBufferSize), follow: true, search: newSearchInput()}
+    for _, text := range lines {
+        m.logs.buffer.Append(docker.LogLine{Stream: docker.StreamStdout, Text: text})
+    }
+    m.refreshLogView()
+    return m
+}
+
+// search types query into the search prompt and submits it.
+func search(t *testing.T, m Model, query string, regex bool) Model {
+    t.Helper()
+    m, _ = press(t, m, "/")
+    if regex {
+        updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+        m = updated.(Model)
+    }
+    m, _ = press(t, m, query)
+    updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+    return updated.(Model)
+}
+
+func TestSearchNavigationWraps(t *testing.T) {
+    m := newLogTestModel(t, "GET /a 200", "GET /b 500", "healthcheck", "GET /c 500")
+
+    m = search(t, m, "500", false)
+    if got := m.logs.matchRows; len(got) != 2 || got[0] != 1 || got[1] != 3 {
+        t.Fatalf("matchRows = %v, want rows 1 and 3", got)
+    }
+    // The most recent match is current first
+    if m.logs.matchIdx != 1 {
+        t.Errorf("matchIdx = %d, want 1", m.logs.matchIdx)
+    }
+
+    m, _ = press(t, m, "n")
+    if m.logs.matchIdx != 0 {
+        t.Errorf("n after the last match: matchIdx = %d, want 0", m.logs.matchIdx)
+    }
+    m, _ = press(t, m, "N")
+    if m.logs.matchIdx != 1 {
+        t.Errorf("N before the first match: matchIdx = %d, want 1", m.logs.matchIdx)
+    }
+    if m.logs.follow {
+        t.Error("jumping to a match should stop following")
+    }
+
+    m, _ = press(t, m, "o")
+    if rows := strings.Split(m.renderLogs(), "\n"); len(rows) != 2 {
+        t.Errorf("only matches shows %d rows, want 2", len(rows))
+    }
+}
+
+func TestSearchInvalidRegexKeepsPrompt(t *testing.T) {
+    m := newLogTestModel(t, "GET /a 200")
+
+    m = search(t, m, "(", true)
+    if !m.logs.searching || m.logs.searchErr == nil || m.logs.matcher != nil {
+        t.Errorf("searching = %v, err = %v, matcher = %v; want the prompt kept open with an error",
+            m.logs.searching, m.logs.searchErr, m.logs.matcher)
+    }
+}
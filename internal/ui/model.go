@@ -3,7 +3,6 @@ package ui
 import (
     "context"
     "fmt"
-    "io"
     "strings"
     "time"
 
@@ -18,16 +17,41 @@ import (
 )
 
 type Model struct {
-    dockerClient *docker.DockerClient
+    runtime      docker.ContainerRuntime
+    stats        docker.StatsSource
+    events       <-chan docker.ContainerEvent
+    ctx          context.Context
+    cancel       context.CancelFunc
     table        table.Model
     viewport     viewport.Model
     textinput    textinput.Model
     containers   []docker.ContainerInfo
-    selectedID   string
+    // marked holds the IDs of rows marked with space
+    marked       map[string]bool
+    confirm      removeConfirm
+    bulk         bulkState
+    inFlight     map[string]inFlight
+    // stopOpts are used by stop and restart
+    stopOpts     docker.StopOptions
+    toasts       []notification
+    history      []notification
+    notifySeq    int
+    historyPort  viewport.Model
+    historyPrev  ViewType
+    detail       detailState
+    detailPort   viewport.Model
+    kill         killPicker
+    rename       renamePrompt
+    // markByFilter makes the filter prompt mark matches instead of
+    // filtering the list
+    markByFilter bool
+    logs         logState
     currentView  ViewType
-    err          error
+    conn         connState
     loading      bool
     filter       string
+    filters      []docker.Filter
+    filterErr    error
     compactMode  bool
     width        int
     height       int
@@ -39,13 +63,17 @@ const (
     ContainersView ViewType = iota
     LogsView
     FilterView
+    ConfirmView
+    SignalView
+    HistoryView
+    DetailView
 )
 
 type tickMsg time.Time
 type containersMsg []docker.ContainerInfo
 type errorMsg struct{ error }
 
-func NewModel(dockerClient *docker.DockerClient, compact bool) Model {
+func NewModel(runtime docker.ContainerRuntime, compact bool) Model {
     // Initialize table
     columns := []table.Column{
         {Title: "ID", Width: 12},
@@ -55,7 +83,9 @@ func NewModel(dockerClient *docker.DockerClient, compact bool) Model {
         {Title: "Ports", Width: 20},
         {Title: "CPU%", Width: 8},
         {Title: "Memory%", Width: 10},
-        {Title: "Network", Width: 15},
+        {Title: "Network", Width: 22},
+        {Title: "Block I/O", Width: 20},
+        {Title: "PIDs", Width: 6},
         {Title: "Uptime", Width: 15},
     }
 
@@ -90,29 +120,49 @@ func NewModel(dockerClient *docker.DockerClient, compact bool) Model {
 
     // Initialize text input for filtering
     ti := textinput.New()
-    ti.Placeholder = "Filter by name, status..."
-    ti.CharLimit = 50
+    ti.Placeholder = "name or key=value, e.g. web status=running label=tier=db"
+    ti.CharLimit = 100
     ti.Width = 50
 
+    // Stats and events are streamed in the background for as long as the
+    // UI runs
+    ctx, cancel := context.WithCancel(context.Background())
+
     return Model{
-        dockerClient: dockerClient,
+        runtime:      runtime,
+        stats:        runtime.WatchStats(ctx),
+        events:       runtime.WatchEvents(ctx),
+        ctx:          ctx,
+        cancel:       cancel,
         table:        t,
         viewport:     vp,
+        historyPort:  viewport.New(80, 20),
+        detailPort:   viewport.New(80, 20),
         textinput:    ti,
+        marked:       make(map[string]bool),
+        inFlight:     make(map[string]inFlight),
         currentView:  ContainersView,
         compactMode:  compact,
     }
 }
 
+// SetStopOptions sets the timeout and signal used to stop and restart
+// containers.
+func (m *Model) SetStopOptions(opts docker.StopOptions) {
+    m.stopOpts = opts
+}
+
 func (m Model) Init() tea.Cmd {
     return tea.Batch(
         m.refreshContainers(),
+        pingCmd(m.ctx, m.runtime),
+        waitForEvent(m.events),
         tickCmd(),
+        resyncCmd(),
     )
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-    var cmd tea.Cmd
     var cmds []tea.Cmd
 
     switch msg := msg.(type) {
@@ -124,6 +174,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
             return m.updateLogsView(msg)
         case FilterView:
             return m.updateFilterView(msg)
+        case ConfirmView:
+            return m.updateConfirmView(msg)
+        case SignalView:
+            return m.updateSignalView(msg)
+        case HistoryView:
+            return m.updateHistoryView(msg)
+        case DetailView:
+            return m.updateDetailView(msg)
         }
 
     case tea.WindowSizeMsg:
@@ -132,103 +190,228 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
         m.table.SetHeight(msg.Height - 10)
         m.viewport.Height = msg.Height - 10
         m.viewport.Width = msg.Width - 4
+        m.historyPort.Height = msg.Height - 6
+        m.historyPort.Width = msg.Width - 4
+        m.detailPort.Height = msg.Height - 8
+        m.detailPort.Width = msg.Width - 4
 
     case containersMsg:
         m.loading = false
+        m.conn.lastUpdate = time.Now()
         m.containers = msg
+        m.pruneMarks()
         m.updateTableRows()
-        cmds = append(cmds, tickCmd())
+
+    case logStreamMsg, logLinesMsg, logEndMsg, logExportMsg:
+        cmds = append(cmds, m.updateLogStream(msg))
+
+    case bulkResultMsg, bulkDoneMsg:
+        cmds = append(cmds, m.updateBulk(msg))
+
+    case containerEventMsg:
+        cmds = append(cmds, m.applyEvent(docker.ContainerEvent(msg)), waitForEvent(m.events))
 
     case errorMsg:
-        m.err = msg.error
         m.loading = false
+        // Losing the daemon starts reconnecting; anything else is reported
+        // and the UI carries on
+        if docker.IsConnectionError(msg.error) {
+            cmds = append(cmds, m.disconnect(msg.error))
+        } else {
+            cmds = append(cmds, m.notify(notifyError, "%v", msg.error))
+        }
+
+    case expireToastMsg:
+        m.expireToast(msg.id)
 
     case tickMsg:
-        cmds = append(cmds, m.refreshContainers(), tickCmd())
+        // Stats come from the background collector, so a tick doesn't need
+        // to go back to the daemon. While disconnected they'd be stale.
+        if !m.conn.disconnected {
+            docker.ApplyStats(m.containers, m.stats.Snapshot())
+        }
+        m.updateTableRows()
+        cmds = append(cmds, tickCmd())
+
+    case resyncMsg:
+        if !m.conn.disconnected {
+            cmds = append(cmds, m.refreshContainers())
+        }
+        cmds = append(cmds, resyncCmd())
+
+    case detailMsg:
+        m.updateDetail(msg)
+
+    case shellExitMsg:
+        cmds = append(cmds, m.updateShellExit(msg))
+
+    case pingMsg, reconnectMsg:
+        cmds = append(cmds, m.updateConnection(msg))
     }
 
     return m, tea.Batch(cmds...)
 }
 
 func (m *Model) updateContainersView(msg tea.KeyMsg) (Model, tea.Cmd) {
+    if m.rename.active {
+        return m.updateRenameInput(msg)
+    }
+
     switch {
     case key.Matches(msg, Keys.Quit):
-        return m, tea.Quit
+        return *m, m.quit()
 
     case key.Matches(msg, Keys.Logs):
-        if m.table.SelectedRow() != nil {
-            m.currentView = LogsView
-            return m, m.loadLogs()
+        if c, ok := m.selectedContainer(); ok {
+            return *m, m.openLogs(c)
+        }
+
+    case key.Matches(msg, Keys.Enter):
+        if c, ok := m.selectedContainer(); ok {
+            return *m, m.openDetail(c)
+        }
+
+    case key.Matches(msg, Keys.Mark):
+        if c, ok := m.selectedContainer(); ok {
+            if m.marked[c.ID] {
+                delete(m.marked, c.ID)
+            } else {
+                m.marked[c.ID] = true
+            }
+            m.updateTableRows()
+            m.table.MoveDown(1)
+        }
+        return *m, nil
+
+    case key.Matches(msg, Keys.MergedLogs):
+        if marked := m.markedContainers(); len(marked) > 0 {
+            return *m, m.openLogs(marked...)
         }
 
     case key.Matches(msg, Keys.Filter):
         m.currentView = FilterView
         m.textinput.Focus()
-        return m, nil
+        return *m, nil
+
+    case key.Matches(msg, Keys.History):
+        m.openHistory()
+        return *m, nil
+
+    case key.Matches(msg, Keys.SelectAll):
+        m.markAll()
+        return *m, nil
+
+    case key.Matches(msg, Keys.MarkByFilter):
+        m.currentView = FilterView
+        m.markByFilter = true
+        m.textinput.SetValue("")
+        m.textinput.Focus()
+        return *m, nil
+
+    case key.Matches(msg, Keys.Back):
+        // Dismiss the summary of the last action
+        if m.bulk.done {
+            m.bulk = bulkState{gen: m.bulk.gen}
+            m.updateTableRows()
+        }
+        return *m, nil
 
     case key.Matches(msg, Keys.Start):
-        return m, m.startContainer()
+        return *m, m.startContainers()
 
     case key.Matches(msg, Keys.Stop):
-        return m, m.stopContainer()
+        return *m, m.stopContainers()
 
     case key.Matches(msg, Keys.Restart):
-        return m, m.restartContainer()
+        return *m, m.restartContainers()
+
+    case key.Matches(msg, Keys.Pause):
+        return *m, m.pauseContainers()
+
+    case key.Matches(msg, Keys.Unpause):
+        return *m, m.unpauseContainers()
+
+    case key.Matches(msg, Keys.Kill):
+        if targets := m.actionTargets(); len(targets) > 0 {
+            m.openKillPicker(targets)
+        }
+        return *m, nil
+
+    case key.Matches(msg, Keys.Rename):
+        if c, ok := m.selectedContainer(); ok {
+            return *m, m.openRename(c)
+        }
+        return *m, nil
+
+    case key.Matches(msg, Keys.Shell):
+        if c, ok := m.selectedContainer(); ok {
+            return *m, m.openShell(c)
+        }
+        return *m, nil
 
     case key.Matches(msg, Keys.Remove):
-        return m, m.removeContainer()
+        if targets := m.actionTargets(); len(targets) > 0 {
+            m.confirmRemove(targets...)
+        }
+        return *m, nil
 
     case key.Matches(msg, Keys.Refresh):
-        return m, m.refreshContainers()
+        if m.conn.disconnected {
+            return *m, m.retryNow()
+        }
+        return *m, m.refreshContainers()
 
     case key.Matches(msg, Keys.Help):
         // Toggle help
-        return m, nil
+        return *m, nil
     }
 
     var cmd tea.Cmd
     m.table, cmd = m.table.Update(msg)
-    return m, cmd
-}
-
-func (m *Model) updateLogsView(msg tea.KeyMsg) (Model, tea.Cmd) {
-    switch {
-    case key.Matches(msg, Keys.Back):
-        m.currentView = ContainersView
-        m.viewport.SetContent("")
-    case key.Matches(msg, Keys.Quit):
-        return m, tea.Quit
-    }
-
-    var cmd tea.Cmd
-    m.viewport, cmd = m.viewport.Update(msg)
-    return m, cmd
+    return *m, cmd
 }
 
 func (m *Model) updateFilterView(msg tea.KeyMsg) (Model, tea.Cmd) {
     switch {
     case key.Matches(msg, Keys.Enter):
-        m.filter = m.textinput.Value()
-        m.currentView = ContainersView
-        m.textinput.Blur()
-        return m, m.refreshContainers()
+        filters, err := parseFilterInput(m.textinput.Value())
+        if err != nil {
+            // Stay in the filter view so the input can be corrected
+            m.filterErr = err
+            return *m, nil
+        }
+        if m.markByFilter {
+            m.markMatching(filters)
+            m.closeFilterView()
+            return *m, nil
+        }
+        m.filter = strings.TrimSpace(m.textinput.Value())
+        m.filters = filters
+        m.closeFilterView()
+        return *m, m.refreshContainers()
 
     case key.Matches(msg, Keys.Back):
-        m.currentView = ContainersView
-        m.textinput.Blur()
-        return m, nil
+        m.closeFilterView()
+        return *m, nil
     }
 
     var cmd tea.Cmd
     m.textinput, cmd = m.textinput.Update(msg)
-    return m, cmd
+    return *m, cmd
 }
 
-func (m Model) View() string {
-    if m.err != nil {
-        return fmt.Sprintf("Error: %v\nPress q to quit", m.err)
+func (m *Model) closeFilterView() {
+    m.currentView = ContainersView
+    m.filterErr = nil
+    m.textinput.Blur()
+    if m.markByFilter {
+        // Put back the list filter the prompt was borrowed from
+        m.markByFilter = false
+        m.textinput.SetValue(m.filter)
     }
+}
 
+func (m Model) View() string {
     var view string
     switch m.currentView {
     case ContainersView:
@@ -237,8 +420,19 @@ func (m Model) View() string {
         view = m.logsView()
     case FilterView:
         view = m.filterView()
+    case ConfirmView:
+        view = m.confirmView()
+    case SignalView:
+        view = m.signalView()
+    case HistoryView:
+        view = m.historyView()
+    case DetailView:
+        view = m.detailView()
     }
 
+    if toasts := m.toastView(); toasts != "" {
+        view += "\n\n" + toasts
+    }
     return view
 }
 
@@ -247,53 +441,69 @@ func (m Model) containersView() string {
 
     // Title
     b.WriteString(TitleStyle.Render("🐳 Docker Container Manager"))
+    if m.conn.server.Version != "" {
+        b.WriteString(HelpStyle.Render(m.conn.server.String()))
+    }
     b.WriteString("\n\n")
 
+    if banner := m.connectionBanner(); banner != "" {
+        b.WriteString(banner)
+        b.WriteString("\n\n")
+    }
+
     // Table
     b.WriteString(m.table.View())
     b.WriteString("\n\n")
 
     // Status bar
     status := fmt.Sprintf("Containers: %d", len(m.containers))
+    if len(m.marked) > 0 {
+        status += fmt.Sprintf(" | Marked: %d", len(m.marked))
+    }
     if m.filter != "" {
         status += fmt.Sprintf(" | Filter: %s", m.filter)
     }
-    if m.loading {
+    if m.conn.disconnected {
+        status += " | STALE, last updated " + m.conn.lastUpdate.Format("15:04:05")
+    } else if m.loading {
         status += " | Refreshing..."
     }
+    if bulk := m.bulkStatus(); bulk != "" {
+        status += " | " + bulk
+    }
     b.WriteString(StatusBarStyle.Render(status))
     b.WriteString("\n\n")
 
+    if m.rename.active {
+        b.WriteString(m.renameView())
+        b.WriteString("\n\n")
+    }
+
     // Help
     b.WriteString(m.helpView())
 
     return b.String()
 }
 
-func (m Model) logsView() string {
-    var b strings.Builder
-
-    b.WriteString(TitleStyle.Render("📋 Logs - " + m.selectedID))
-    b.WriteString("\n\n")
-
-    b.WriteString(m.viewport.View())
-    b.WriteString("\n\n")
-
-    b.WriteString(HelpStyle.Render("↑/↓: Scroll • q/esc: Back to containers"))
-
-    return b.String()
-}
-
 func (m Model) filterView() string {
     var b strings.Builder
 
-    b.WriteString(TitleStyle.Render("🔍 Filter Containers"))
+    title := "🔍 Filter Containers"
+    if m.markByFilter {
+        title = "🔍 Mark Matching Containers"
+    }
+    b.WriteString(TitleStyle.Render(title))
     b.WriteString("\n\n")
 
-    b.WriteString("Enter filter (name, status):\n")
+    b.WriteString("Enter filter (name or key=value for label, status, name, ancestor, network, health, project):\n")
     b.WriteString(m.textinput.View())
     b.WriteString("\n\n")
 
+    if m.filterErr != nil {
+        b.WriteString(ContainerStoppedStyle.Render(m.filterErr.Error()))
+        b.WriteString("\n\n")
+    }
+
     b.WriteString(HelpStyle.Render("Enter: Apply • esc: Cancel"))
 
     return b.String()
@@ -302,158 +512,138 @@ func (m Model) filterView() string {
 func (m Model) helpView() string {
     if m.currentView == ContainersView {
         return HelpStyle.Render(
-            "←/→/↑/↓: Navigate • s: Start • t: Stop • r: Restart • d: Remove • l: Logs • f: Filter • F5: Refresh • q: Quit",
+            "←/→/↑/↓: Navigate • enter: Details • space: Mark • a: Mark all • m: Mark matching • s: Start • t: Stop • r: Restart • p/u: Pause/unpause • K: Kill • R: Rename • e: Shell • d: Remove • l: Logs • M: Merged logs of marked • f: Filter • H: History • F5: Refresh • q: Quit",
         )
     }
     return ""
 }
 
 // Command functions
+func (m *Model) quit() tea.Cmd {
+    m.cancel()
+    return tea.Quit
+}
+
 func (m *Model) refreshContainers() tea.Cmd {
+    runtime, stats := m.runtime, m.stats
+    opts := docker.ListOptions{All: true, SkipStats: true, Filters: m.filters}
+    m.loading = true
     return func() tea.Msg {
-        m.loading = true
-        containers, err := m.dockerClient.ListContainers(true)
+        containers, err := runtime.ListContainers(opts)
         if err != nil {
             return errorMsg{err}
         }
-
-        // Apply filter
-        if m.filter != "" {
-            filtered := []docker.ContainerInfo{}
-            for _, c := range containers {
-                if strings.Contains(strings.ToLower(c.Name), strings.ToLower(m.filter)) ||
-                    strings.Contains(strings.ToLower(c.Status), strings.ToLower(m.filter)) ||
-                    strings.Contains(strings.ToLower(c.Image), strings.ToLower(m.filter)) {
-                    filtered = append(filtered, c)
-                }
-            }
-            containers = filtered
-        }
-
+        docker.ApplyStats(containers, stats.Snapshot())
         return containersMsg(containers)
     }
 }
 
-func (m *Model) loadLogs() tea.Cmd {
-    return func() tea.Msg {
-        if m.table.SelectedRow() == nil {
-            return errorMsg{fmt.Errorf("no container selected")}
-        }
-
-        containerID := m.table.SelectedRow()[0]
-        m.selectedID = containerID
-
-        logs, err := m.dockerClient.GetContainerLogs(containerID)
-        if err != nil {
-            return errorMsg{err}
-        }
-
-        m.viewport.SetContent(logs)
-        m.viewport.GotoBottom()
-
-        return nil
+// selectedContainer returns the container under the cursor.
+func (m *Model) selectedContainer() (docker.ContainerInfo, bool) {
+    row := m.table.SelectedRow()
+    if row == nil {
+        return docker.ContainerInfo{}, false
     }
-}
-
-func (m *Model) startContainer() tea.Cmd {
-    return func() tea.Msg {
-        if m.table.SelectedRow() == nil {
-            return errorMsg{fmt.Errorf("no container selected")}
-        }
-
-        containerID := m.table.SelectedRow()[0]
-        if err := m.dockerClient.StartContainer(containerID); err != nil {
-            return errorMsg{err}
+    for _, c := range m.containers {
+        if c.ID == row[0] {
+            return c, true
         }
-
-        return m.refreshContainers()()
     }
+    return docker.ContainerInfo{}, false
 }
 
-func (m *Model) stopContainer() tea.Cmd {
-    return func() tea.Msg {
-        if m.table.SelectedRow() == nil {
-            return errorMsg{fmt.Errorf("no container selected")}
-        }
-
-        containerID := m.table.SelectedRow()[0]
-        if err := m.dockerClient.StopContainer(containerID); err != nil {
-            return errorMsg{err}
+// markedContainers returns the marked containers in list order.
+func (m *Model) markedContainers() []docker.ContainerInfo {
+    var marked []docker.ContainerInfo
+    for _, c := range m.containers {
+        if m.marked[c.ID] {
+            marked = append(marked, c)
         }
-
-        return m.refreshContainers()()
     }
+    return marked
 }
 
-func (m *Model) restartContainer() tea.Cmd {
-    return func() tea.Msg {
-        if m.table.SelectedRow() == nil {
-            return errorMsg{fmt.Errorf("no container selected")}
-        }
-
-        containerID := m.table.SelectedRow()[0]
-        if err := m.dockerClient.RestartContainer(containerID); err != nil {
-            return errorMsg{err}
-        }
-
-        return m.refreshContainers()()
+// pruneMarks forgets marks on containers that are no longer listed.
+func (m *Model) pruneMarks() {
+    listed := make(map[string]bool, len(m.containers))
+    for _, c := range m.containers {
+        listed[c.ID] = true
     }
-}
-
-func (m *Model) removeContainer() tea.Cmd {
-    return func() tea.Msg {
-        if m.table.SelectedRow() == nil {
-            return errorMsg{fmt.Errorf("no container selected")}
-        }
-
-        containerID := m.table.SelectedRow()[0]
-        // In a real implementation, we'd show a confirmation dialog
-        if err := m.dockerClient.RemoveContainer(containerID); err != nil {
-            return errorMsg{err}
+    for id := range m.marked {
+        if !listed[id] {
+            delete(m.marked, id)
         }
-
-        return m.refreshContainers()()
     }
 }
 
 func (m *Model) updateTableRows() {
     var rows []table.Row
     for _, c := range m.containers {
-        var statusStyle lipgloss.Style
-        switch {
-        case strings.Contains(c.Status, "Up"):
-            statusStyle = ContainerRunningStyle
-        case strings.Contains(c.Status, "Exited"):
-            statusStyle = ContainerStoppedStyle
-        default:
-            statusStyle = ContainerPausedStyle
+        name := m.bulkMarker(c.ID) + c.Name
+        if m.marked[c.ID] {
+            name = MarkedStyle.Render("●") + " " + name
+        }
+
+        status := containerStatusStyle(c.Status).Render(c.Status)
+        if s, ok := m.inFlightStatus(c.ID); ok {
+            status = s
         }
 
         uptime := time.Since(c.Created).Truncate(time.Second).String()
 
+        blockIO, pids := "", ""
+        if c.HasStats {
+            pids = docker.FormatPIDs(c.PIDs, c.PIDsLimit)
+        }
+        if c.HasRates {
+            blockIO = docker.FormatRate(c.BlockReadRate) + " " + docker.FormatRate(c.BlockWriteRate)
+        }
+
         if m.compactMode {
             rows = append(rows, table.Row{
                 c.ID,
-                c.Name,
-                statusStyle.Render(c.Status),
+                name,
+                status,
                 GetUsageStyle(c.CPU).Render(fmt.Sprintf("%.1f", c.CPU)),
                 GetUsageStyle(c.Memory).Render(fmt.Sprintf("%.1f", c.Memory)),
             })
         } else {
             rows = append(rows, table.Row{
                 c.ID,
-                c.Name,
+                name,
                 c.Image,
-                statusStyle.Render(c.Status),
+                status,
                 c.Ports,
                 GetUsageStyle(c.CPU).Render(fmt.Sprintf("%.1f", c.CPU)),
                 GetUsageStyle(c.Memory).Render(fmt.Sprintf("%.1f", c.Memory)),
                 c.Network,
+                blockIO,
+                pids,
                 uptime,
             })
         }
     }
     m.table.SetRows(rows)
+    // The table doesn't move its cursor when rows go away, which would
+    // leave nothing selected once the last row is removed
+    if n := len(rows); n > 0 && m.table.Cursor() >= n {
+        m.table.SetCursor(n - 1)
+    }
+}
+
+// containerStatusStyle colours a status such as "Up 3 minutes". Paused
+// containers are "Up ... (Paused)", so that is checked first.
+func containerStatusStyle(status string) lipgloss.Style {
+    switch {
+    case strings.Contains(status, "Paused"):
+        return ContainerPausedStyle
+    case strings.Contains(status, "Up"):
+        return ContainerRunningStyle
+    case strings.Contains(status, "Exited"):
+        return ContainerStoppedStyle
+    default:
+        return ContainerPausedStyle
+    }
 }
 
 func tickCmd() tea.Cmd {
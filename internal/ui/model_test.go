@@ -0,0 +1,66 @@
+package ui
+
+import (
+    "errors"
+    "testing"
+
+    "docker-manager/internal/docker"
+
+    tea "github.com/charmbracelet/bubbletea"
+    "github.com/charmbracelet/lipgloss"
+)
+
+var errTest = errors.New("daemon gone")
+
+func newTestModel(t *testing.T) (Model, *docker.FakeRuntime) {
+    t.Helper()
+    fake := docker.NewFakeRuntime(
+        docker.ContainerInfo{ID: "aaaaaaaaaaaa", Name: "web", State: "running", Status: "Up 1 hour"},
+        docker.ContainerInfo{ID: "bbbbbbbbbbbb", Name: "db", State: "running", Status: "Up 1 hour"},
+    )
+    m := NewModel(fake, false)
+    t.Cleanup(m.cancel)
+
+    containers, err := fake.ListContainers(docker.ListOptions{All: true})
+    if err != nil {
+        t.Fatal(err)
+    }
+    updated, _ := m.Update(containersMsg(containers))
+    return updated.(Model), fake
+}
+
+func press(t *testing.T, m Model, keys string) (Model, tea.Cmd) {
+    t.Helper()
+    updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(keys)})
+    return updated.(Model), cmd
+}
+
+func TestRefreshFailureIsNotified(t *testing.T) {
+    m, fake := newTestModel(t)
+    fake.Fail("ListContainers", errTest)
+
+    updated, _ := m.Update(m.refreshContainers()())
+    m = updated.(Model)
+    if m.conn.disconnected || len(m.history) != 1 || m.history[0].text != "daemon gone" {
+        t.Errorf("disconnected = %v, history = %+v; want a notification only", m.conn.disconnected, m.history)
+    }
+}
+
+func TestContainerStatusStyle(t *testing.T) {
+    tests := []struct {
+        status string
+        want   lipgloss.TerminalColor
+    }{
+        {"Up 3 minutes", SuccessColor},
+        {"Up 3 minutes (healthy)", SuccessColor},
+        {"Up 3 minutes (Paused)", WarningColor},
+        {"Exited (0) 2 hours ago", DangerColor},
+        {"Created", WarningColor},
+    }
+    for _, tt := range tests {
+        // Rendering is colourless without a terminal, so compare the colours
+        if got := containerStatusStyle(tt.status).GetForeground(); got != tt.want {
+            t.Errorf("%q: foreground = %v, want %v", tt.status, got, tt.want)
+        }
+    }
+}
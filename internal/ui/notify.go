@@ -0,0 +1,135 @@
+package ui
+
+import (
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/charmbracelet/bubbles/key"
+    tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+    // toastDuration is how long a notification stays on screen
+    toastDuration = 4 * time.Second
+    // maxToasts is how many notifications are shown at once
+    maxToasts = 3
+    // historySize bounds the notification history
+    historySize = 200
+)
+
+type notifyLevel int
+
+const (
+    notifyInfo notifyLevel = iota
+    notifySuccess
+    notifyError
+)
+
+type notification struct {
+    id    int
+    level notifyLevel
+    text  string
+    at    time.Time
+}
+
+type expireToastMsg struct {
+    id int
+}
+
+// notify shows a transient toast and records it in the history.
+func (m *Model) notify(level notifyLevel, format string, args ...interface{}) tea.Cmd {
+    m.notifySeq++
+    n := notification{
+        id:    m.notifySeq,
+        level: level,
+        text:  fmt.Sprintf(format, args...),
+        at:    time.Now(),
+    }
+
+    m.history = append(m.history, n)
+    if len(m.history) > historySize {
+        m.history = m.history[len(m.history)-historySize:]
+    }
+    m.toasts = append(m.toasts, n)
+    if len(m.toasts) > maxToasts {
+        m.toasts = m.toasts[len(m.toasts)-maxToasts:]
+    }
+    if m.currentView == HistoryView {
+        m.historyPort.SetContent(m.renderHistory())
+    }
+
+    return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+        return expireToastMsg{id: n.id}
+    })
+}
+
+func (m *Model) expireToast(id int) {
+    for i, t := range m.toasts {
+        if t.id == id {
+            m.toasts = append(m.toasts[:i], m.toasts[i+1:]...)
+            return
+        }
+    }
+}
+
+func notificationStyle(level notifyLevel) func(...string) string {
+    switch level {
+    case notifySuccess:
+        return ToastSuccessStyle.Render
+    case notifyError:
+        return ToastErrorStyle.Render
+    }
+    return ToastInfoStyle.Render
+}
+
+func (m Model) toastView() string {
+    var lines []string
+    for _, t := range m.toasts {
+        lines = append(lines, notificationStyle(t.level)(t.text))
+    }
+    return strings.Join(lines, "\n")
+}
+
+func (m *Model) openHistory() {
+    m.historyPrev = m.currentView
+    m.currentView = HistoryView
+    m.historyPort.SetContent(m.renderHistory())
+    m.historyPort.GotoBottom()
+}
+
+func (m Model) renderHistory() string {
+    if len(m.history) == 0 {
+        return HelpStyle.Render("No actions yet")
+    }
+    lines := make([]string, len(m.history))
+    for i, n := range m.history {
+        lines[i] = n.at.Format("15:04:05") + "  " + notificationStyle(n.level)(n.text)
+    }
+    return strings.Join(lines, "\n")
+}
+
+func (m *Model) updateHistoryView(msg tea.KeyMsg) (Model, tea.Cmd) {
+    switch {
+    case key.Matches(msg, Keys.Back), key.Matches(msg, Keys.History):
+        m.currentView = m.historyPrev
+        return *m, nil
+
+    case key.Matches(msg, Keys.Quit):
+        return *m, m.quit()
+    }
+
+    var cmd tea.Cmd
+    m.historyPort, cmd = m.historyPort.Update(msg)
+    return *m, cmd
+}
+
+func (m Model) historyView() string {
+    var b strings.Builder
+    b.WriteString(TitleStyle.Render("🕘 Action History"))
+    b.WriteString("\n\n")
+    b.WriteString(m.historyPort.View())
+    b.WriteString("\n\n")
+    b.WriteString(HelpStyle.Render("↑/↓: Scroll • H/esc: Back • q: Quit"))
+    return b.String()
+}
@@ -26,6 +26,13 @@ var (
     ContainerStoppedStyle = lipgloss.NewStyle().Foreground(DangerColor)
     ContainerPausedStyle  = lipgloss.NewStyle().Foreground(WarningColor)
 
+    MarkedStyle = lipgloss.NewStyle().Foreground(WarningColor).Bold(true)
+
+    // Bulk action progress
+    BulkPendingStyle = lipgloss.NewStyle().Foreground(WarningColor)
+    BulkDoneStyle    = lipgloss.NewStyle().Foreground(SuccessColor)
+    BulkFailedStyle  = lipgloss.NewStyle().Foreground(DangerColor).Bold(true)
+
     // Table styles
     HeaderStyle = lipgloss.NewStyle().
         Foreground(PrimaryColor).
@@ -44,6 +51,62 @@ var (
     MediumUsageStyle = lipgloss.NewStyle().Foreground(WarningColor)
     LowUsageStyle = lipgloss.NewStyle().Foreground(SuccessColor)
 
+    // Log styles
+    LogStderrStyle = lipgloss.NewStyle().Foreground(DangerColor)
+    LogErrorStyle  = lipgloss.NewStyle().Foreground(DangerColor).Bold(true)
+    LogWarnStyle   = lipgloss.NewStyle().Foreground(WarningColor)
+    LogInfoStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+    LogDebugStyle  = lipgloss.NewStyle().Foreground(MutedColor)
+
+    // LogPrefixColors tell containers apart in a merged log view
+    LogPrefixColors = []lipgloss.Color{"51", "214", "46", "201", "69", "226"}
+
+    // Search styles
+    SearchMatchStyle = lipgloss.NewStyle().
+        Background(WarningColor).
+        Foreground(lipgloss.Color("0"))
+    CurrentMatchStyle = lipgloss.NewStyle().
+        Background(PrimaryColor).
+        Foreground(lipgloss.Color("15")).
+        Bold(true)
+
+    // Detail view styles
+    TabStyle = lipgloss.NewStyle().
+        Foreground(MutedColor).
+        Padding(0, 1)
+    ActiveTabStyle = lipgloss.NewStyle().
+        Foreground(lipgloss.Color("15")).
+        Background(PrimaryColor).
+        Bold(true).
+        Padding(0, 1)
+    DetailLabelStyle = lipgloss.NewStyle().Foreground(PrimaryColor)
+
+    // Dialog styles
+    ConfirmStyle = lipgloss.NewStyle().
+        Border(lipgloss.RoundedBorder()).
+        BorderForeground(DangerColor).
+        Padding(1, 2)
+
+    DisconnectedStyle = lipgloss.NewStyle().
+        Foreground(lipgloss.Color("0")).
+        Background(WarningColor).
+        Bold(true).
+        Padding(0, 1)
+
+    // Notification styles
+    ToastInfoStyle = lipgloss.NewStyle().
+        Foreground(lipgloss.Color("15")).
+        Background(MutedColor).
+        Padding(0, 1)
+    ToastSuccessStyle = lipgloss.NewStyle().
+        Foreground(lipgloss.Color("0")).
+        Background(SuccessColor).
+        Padding(0, 1)
+    ToastErrorStyle = lipgloss.NewStyle().
+        Foreground(lipgloss.Color("15")).
+        Background(DangerColor).
+        Padding(0, 1)
+
     // Help styles
     HelpStyle = lipgloss.NewStyle().
         Foreground(MutedColor).